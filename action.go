@@ -68,6 +68,39 @@ func (a Action) WithBaseHref(base Href) Action {
 	}
 }
 
+// WithoutBaseHref returns a copy of this action that removes the supplied base
+// href from the action's href. This is the inverse of WithBaseHref.
+func (a Action) WithoutBaseHref(base Href) Action {
+	return Action{
+		Name:   a.Name,
+		Href:   a.Href.Relative(base),
+		Method: a.Method,
+		Fields: a.Fields,
+		Type:   a.Type,
+		Title:  a.Title,
+		Class:  a.Class,
+	}
+}
+
+// MapHrefs returns a copy of this action with the href replaced by the result
+// of the mapper.
+func (a Action) MapHrefs(fn HrefMapper) (Action, error) {
+	href, err := fn(HrefKindAction, a.Href)
+	if err != nil {
+		return Action{}, err
+	}
+
+	return Action{
+		Name:   a.Name,
+		Href:   href,
+		Method: a.Method,
+		Fields: a.Fields,
+		Type:   a.Type,
+		Title:  a.Title,
+		Class:  a.Class,
+	}, nil
+}
+
 // ActionField is a single field within the larger action.
 type ActionField struct {
 	Name  string  `json:"name" validate:"nonzero"`
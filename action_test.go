@@ -66,6 +66,19 @@ func TestActionWithBaseHref(t *testing.T) {
 	}
 }
 
+func TestActionWithoutBaseHref(t *testing.T) {
+	a := Action{
+		Name: "search",
+		Href: "https://api.example.com/search",
+	}
+	expected := Action{
+		Name: "search",
+		Href: "/search",
+	}
+	require.EqualValues(t, expected, a.WithoutBaseHref("https://api.example.com"))
+	require.EqualValues(t, a, a.WithoutBaseHref("https://example.com"))
+}
+
 func TestActionGetMethod(t *testing.T) {
 	t.Run("default", func(t *testing.T) {
 		a := Action{}
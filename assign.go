@@ -0,0 +1,356 @@
+package siren
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultTimeLayouts are tried in order when decoding a string into a
+// time.Time, covering RFC 3339 along with the HTML5 datetime-local and date
+// input formats.
+var defaultTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// assigner converts loosely-typed values, such as those decoded from JSON or
+// submitted with a form, into arbitrary Go values.
+type assigner struct {
+	timeLayouts []string
+	strict      bool
+	useNumber   bool
+}
+
+func newAssigner(opts ...DecodeOption) *assigner {
+	a := &assigner{timeLayouts: defaultTimeLayouts}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// target checks that v is a non-nil pointer, returning the value it points to.
+func target(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return reflect.Value{}, fmt.Errorf("siren: decode requires a non-nil pointer, got %T", v)
+	}
+	return rv.Elem(), nil
+}
+
+// decodeInto assigns src to the value pointed to by v, which must be a non-nil
+// pointer.
+func (a *assigner) decodeInto(v any, src any) error {
+	dst, err := target(v)
+	if err != nil {
+		return err
+	}
+	return a.assign(dst, copyData(src, a.useNumber), "")
+}
+
+// assign stores src in dst, converting it as needed. The path is used to
+// describe where problems are found. (eg: "address.city")
+func (a *assigner) assign(dst reflect.Value, src any, path string) error {
+	if src == nil {
+		return nil
+	}
+
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return a.assign(dst.Elem(), src, path)
+	}
+
+	if s, ok := src.(string); ok {
+		if dst.Type() == timeType {
+			t, err := a.parseTime(s)
+			if err != nil {
+				return fieldError(path, err)
+			}
+			dst.Set(reflect.ValueOf(t))
+			return nil
+		}
+
+		if dst.CanAddr() && dst.Addr().Type().Implements(textUnmarshalerType) {
+			u := dst.Addr().Interface().(encoding.TextUnmarshaler)
+			return fieldError(path, u.UnmarshalText([]byte(s)))
+		}
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		switch src := src.(type) {
+		case string:
+			dst.SetString(src)
+			return nil
+		case json.Number:
+			dst.SetString(src.String())
+			return nil
+		}
+
+	case reflect.Bool:
+		switch src := src.(type) {
+		case bool:
+			dst.SetBool(src)
+			return nil
+		case string:
+			b, err := parseBool(src)
+			if err != nil {
+				return fieldError(path, err)
+			}
+			dst.SetBool(b)
+			return nil
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := toInt(sv)
+		if err != nil {
+			return fieldError(path, err)
+		}
+		if dst.OverflowInt(n) {
+			return fieldError(path, fmt.Errorf("%d overflows %s", n, dst.Type()))
+		}
+		dst.SetInt(n)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := toInt(sv)
+		if err != nil {
+			return fieldError(path, err)
+		}
+		if n < 0 || dst.OverflowUint(uint64(n)) {
+			return fieldError(path, fmt.Errorf("%d overflows %s", n, dst.Type()))
+		}
+		dst.SetUint(uint64(n))
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat(sv)
+		if err != nil {
+			return fieldError(path, err)
+		}
+		if dst.OverflowFloat(f) {
+			return fieldError(path, fmt.Errorf("%v overflows %s", f, dst.Type()))
+		}
+		dst.SetFloat(f)
+		return nil
+
+	case reflect.Slice:
+		if sv.Kind() != reflect.Slice && sv.Kind() != reflect.Array {
+			// a single value, such as a form field submitted once
+			s := reflect.MakeSlice(dst.Type(), 1, 1)
+			if err := a.assign(s.Index(0), src, path); err != nil {
+				return err
+			}
+			dst.Set(s)
+			return nil
+		}
+
+		s := reflect.MakeSlice(dst.Type(), sv.Len(), sv.Len())
+		for i := 0; i < sv.Len(); i++ {
+			if err := a.assign(s.Index(i), sv.Index(i).Interface(), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+		dst.Set(s)
+		return nil
+
+	case reflect.Map:
+		if sv.Kind() != reflect.Map || sv.Type().Key().Kind() != reflect.String || dst.Type().Key().Kind() != reflect.String {
+			break
+		}
+
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(dst.Type(), sv.Len()))
+		}
+		iter := sv.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := a.assign(elem, iter.Value().Interface(), joinPath(path, key)); err != nil {
+				return err
+			}
+			dst.SetMapIndex(reflect.ValueOf(key).Convert(dst.Type().Key()), elem)
+		}
+		return nil
+
+	case reflect.Struct:
+		if m, ok := src.(map[string]any); ok {
+			return a.assignStruct(dst, m, path)
+		}
+	}
+
+	return fieldError(path, fmt.Errorf("cannot decode %T into %s", src, dst.Type()))
+}
+
+// assignStruct stores each value of src in the property field with a matching
+// name. Values without a matching field are ignored.
+func (a *assigner) assignStruct(dst reflect.Value, src map[string]any, path string) error {
+	fields, err := structFields(dst.Type())
+	if err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(src) {
+		f, ok := fields.lookup(key)
+		if !ok {
+			if a.strict {
+				return fmt.Errorf("%w: %q", ErrUnknownProperty, joinPath(path, key))
+			}
+			continue
+		}
+		if err := a.assign(fieldByIndex(dst, f.index), src[key], joinPath(path, key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *assigner) parseTime(s string) (time.Time, error) {
+	for _, layout := range a.timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as a time", s)
+}
+
+func toInt(v reflect.Value) (int64, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != float64(int64(f)) {
+			return 0, fmt.Errorf("%v is not an integer", f)
+		}
+		return int64(f), nil
+	case reflect.String:
+		// includes json.Number
+		s := strings.TrimSpace(v.String())
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n, nil
+		}
+		// allow integral values written with a fraction or exponent (eg: "1e3")
+		if f, err := strconv.ParseFloat(s, 64); err == nil && f == float64(int64(f)) {
+			return int64(f), nil
+		}
+		return 0, fmt.Errorf("cannot parse %q as an integer", v.String())
+	}
+	return 0, fmt.Errorf("cannot decode %s into an integer", v.Type())
+}
+
+func toFloat(v reflect.Value) (float64, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		// includes json.Number
+		f, err := strconv.ParseFloat(strings.TrimSpace(v.String()), 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot parse %q as a number", v.String())
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("cannot decode %s into a number", v.Type())
+}
+
+// parseBool accepts the values understood by strconv.ParseBool, along with
+// "on" and "off" which browsers send for checkboxes.
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "on":
+		return true, nil
+	case "off", "":
+		return false, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("cannot parse %q as a boolean", s)
+	}
+	return b, nil
+}
+
+// copyData deeply copies maps and slices holding any values, so decoded values
+// never share them with the source. When useNumber is true, every number is
+// replaced with a json.Number.
+func copyData(v any, useNumber bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, value := range v {
+			m[key] = copyData(value, useNumber)
+		}
+		return m
+	case Properties:
+		return copyData(map[string]any(v), useNumber)
+	case []any:
+		s := make([]any, len(v))
+		for i, value := range v {
+			s[i] = copyData(value, useNumber)
+		}
+		return s
+	}
+
+	if !useNumber {
+		return v
+	}
+
+	switch v := v.(type) {
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'f', -1, 64))
+	case float32:
+		return json.Number(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return json.Number(fmt.Sprint(v))
+	}
+	return v
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func fieldError(path string, err error) error {
+	if err == nil || path == "" {
+		return err
+	}
+	return fmt.Errorf("field %q: %w", path, err)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
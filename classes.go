@@ -3,3 +3,13 @@ package siren
 // Classes is a collection of application-specific class names to describe
 // resources.
 type Classes []string
+
+// Contains reports whether the given class name is in this collection.
+func (c Classes) Contains(class string) bool {
+	for _, candidate := range c {
+		if candidate == class {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package client
+
+import "net/http"
+
+// AuthProvider adds credentials to outgoing requests.
+type AuthProvider interface {
+	Authenticate(req *http.Request) error
+}
+
+// AuthProviderFunc is an adapter to allow using a plain function as an
+// AuthProvider.
+type AuthProviderFunc func(req *http.Request) error
+
+// Authenticate calls f(req).
+func (f AuthProviderFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// BearerToken returns an AuthProvider that sends the given token using the
+// Bearer authorization scheme.
+func BearerToken(token string) AuthProvider {
+	return AuthProviderFunc(func(req *http.Request) error {
+		req.Header.Set("authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// BasicAuth returns an AuthProvider that sends the given username and password
+// using the Basic authorization scheme.
+func BasicAuth(username, password string) AuthProvider {
+	return AuthProviderFunc(func(req *http.Request) error {
+		req.SetBasicAuth(username, password)
+		return nil
+	})
+}
@@ -2,86 +2,306 @@ package client
 
 import (
 	"bytes"
-	"encoding/json"
-	"fmt"
+	"context"
 	"io"
 	"net/http"
 	"net/url"
-	"strings"
+	"time"
 
 	siren "github.com/dominicbarnes/go-siren"
 )
 
 // Client is used for interacting with a siren HTTP API.
 type Client struct {
-	http *http.Client
+	http      *http.Client
+	baseURL   string
+	header    http.Header
+	auth      AuthProvider
+	timeout   time.Duration
+	userAgent string
+
+	decoders   map[string]Decoder
+	mediaTypes []string
+	encoders   map[string]Encoder
+	brackets   bool
+	validate   bool
+	coerce     bool
 }
 
-// New creates a new siren client.
-func New() *Client {
-	return &Client{http: new(http.Client)}
+// New creates a new siren client, applying any options supplied.
+func New(opts ...ClientOption) *Client {
+	c := &Client{
+		http:   new(http.Client),
+		header: make(http.Header),
+		decoders: map[string]Decoder{
+			siren.MediaType: DecodeJSON,
+		},
+	}
+
+	// the built-in form encoders check for bracket notation when they are used,
+	// so WithBracketNotation never needs to replace them
+	c.encoders = map[string]Encoder{
+		"application/x-www-form-urlencoded": func(_ siren.Action, data map[string]any) (io.Reader, string, error) {
+			return encodeForm(data, c.brackets)
+		},
+		"application/json": EncodeJSON,
+		"multipart/form-data": func(_ siren.Action, data map[string]any) (io.Reader, string, error) {
+			return encodeMultipart(data, c.brackets)
+		},
+		"text/plain": func(_ siren.Action, data map[string]any) (io.Reader, string, error) {
+			return encodeText(data, c.brackets)
+		},
+		"application/xml": EncodeXML,
+		"text/xml":        EncodeXML,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // Get retrieves the entity at the given href. This is generally used for the
 // entry-point of your application, so prefer using Follow subsequently as your
 // user navigates the API.
 func (c *Client) Get(href string) (*siren.Entity, error) {
-	req, err := http.NewRequest(http.MethodGet, href, nil)
+	return c.GetContext(context.Background(), href)
+}
+
+// GetContext is like Get, but the request is bound to the given context.
+func (c *Client) GetContext(ctx context.Context, href string) (*siren.Entity, error) {
+	return entity(c.DoGet(ctx, href))
+}
+
+// DoGet is like GetContext, but returns the full response.
+func (c *Client) DoGet(ctx context.Context, href string) (*Response, error) {
+	req, err := c.request(ctx, http.MethodGet, href, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.entity(req)
+	return c.Do(req)
 }
 
 // Follow fetches the entity behind the given siren link.
 func (c *Client) Follow(link siren.Link) (*siren.Entity, error) {
-	return c.Get(string(link.Href))
+	return c.FollowContext(context.Background(), link)
+}
+
+// FollowContext is like Follow, but the request is bound to the given context.
+func (c *Client) FollowContext(ctx context.Context, link siren.Link) (*siren.Entity, error) {
+	return entity(c.DoFollow(ctx, link))
+}
+
+// DoFollow is like FollowContext, but returns the full response.
+func (c *Client) DoFollow(ctx context.Context, link siren.Link) (*Response, error) {
+	return c.DoGet(ctx, string(link.Href))
+}
+
+// FollowTemplate expands the templated href of the given link with the
+// supplied variables, then fetches the entity behind it. See siren.Href.Expand
+// for the supported values.
+//
+// Templated hrefs are not resolved when an entity is received, so a relative
+// href is resolved against the base URL (see WithBaseURL) after it has been
+// expanded.
+func (c *Client) FollowTemplate(link siren.Link, vars map[string]any) (*siren.Entity, error) {
+	return c.FollowTemplateContext(context.Background(), link, vars)
+}
+
+// FollowTemplateContext is like FollowTemplate, but the request is bound to the
+// given context.
+func (c *Client) FollowTemplateContext(ctx context.Context, link siren.Link, vars map[string]any) (*siren.Entity, error) {
+	href, err := link.Href.Expand(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	link.Href = href
+	return c.FollowContext(ctx, link)
+}
+
+// FollowEmbedded fetches the full entity behind the given embedded link.
+func (c *Client) FollowEmbedded(embed siren.EmbeddedEntity) (*siren.Entity, error) {
+	return c.FollowEmbeddedContext(context.Background(), embed)
+}
+
+// FollowEmbeddedContext is like FollowEmbedded, but the request is bound to the
+// given context.
+func (c *Client) FollowEmbeddedContext(ctx context.Context, embed siren.EmbeddedEntity) (*siren.Entity, error) {
+	return c.GetContext(ctx, string(embed.Href))
 }
 
 // Submit triggers the given action with data supplied by the user.
 func (c *Client) Submit(action siren.Action, userData map[string]any) (*siren.Entity, error) {
-	u, err := url.Parse(string(action.Href))
+	return c.SubmitContext(context.Background(), action, userData)
+}
+
+// SubmitContext is like Submit, but the request is bound to the given context.
+func (c *Client) SubmitContext(ctx context.Context, action siren.Action, userData map[string]any) (*siren.Entity, error) {
+	return entity(c.DoSubmit(ctx, action, userData))
+}
+
+// DoSubmit is like SubmitContext, but returns the full response.
+func (c *Client) DoSubmit(ctx context.Context, action siren.Action, userData map[string]any) (*Response, error) {
+	if c.validate {
+		if err := ValidateData(action, userData); err != nil {
+			return nil, err
+		}
+	}
+
+	u, err := c.resolve(string(action.Href))
 	if err != nil {
 		return nil, err
 	}
 
 	var body io.Reader
+	contentType := action.GetType()
 	method := action.GetMethod()
 	data := c.data(action, userData)
+	if c.coerce && method != http.MethodGet && isJSON(action.GetType()) {
+		data, err = CoerceData(action, data)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if method == http.MethodGet {
+		values, err := formValues(data, c.brackets)
+		if err != nil {
+			return nil, err
+		}
+
 		q := u.Query()
-		for key, value := range data {
-			q.Set(key, fmt.Sprintf("%v", value))
+		for key, value := range values {
+			q[key] = value
 		}
 		u.RawQuery = q.Encode()
 	} else {
-		switch action.GetType() {
-		case "application/x-www-form-urlencoded":
-			body, err = encodeForm(data)
-		case "application/json":
-			body, err = encodeJSON(data)
-		}
+		body, contentType, err = c.encode(action, data)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), body)
+	req, err := c.request(ctx, method, u.String(), body)
+	if err != nil {
+		if closer, ok := body.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, err
+	}
+
+	req.Header.Set("content-type", contentType)
+
+	return c.Do(req)
+}
+
+// Do sends the given request and decodes the siren entity in the response. The
+// client's default headers, user agent and credentials are applied to the
+// request first. The response body is always read in full and closed.
+//
+// Every href in the decoded entity is resolved against the final URL of the
+// request, so relative hrefs from the server can be used directly with Follow
+// and Submit.
+//
+// When the server responds with a non-2xx status code, an *HTTPError is
+// returned instead of a response.
+func (c *Client) Do(req *http.Request) (*Response, error) {
+	for key, values := range c.header {
+		if _, ok := req.Header[key]; !ok {
+			req.Header[key] = append([]string(nil), values...)
+		}
+	}
+
+	if c.userAgent != "" {
+		req.Header.Set("user-agent", c.userAgent)
+	}
+
+	if c.auth != nil {
+		if err := c.auth.Authenticate(req); err != nil {
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, err
+		}
+	}
+
+	req.Header.Set("accept", c.accept())
+
+	if c.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("content-type", action.GetType())
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		herr := &HTTPError{
+			StatusCode: res.StatusCode,
+			Header:     res.Header,
+			Body:       body,
+		}
+		if decode, ok := c.decoder(res.Header.Get("content-type")); ok {
+			if entity, err := decode(bytes.NewReader(body)); err == nil {
+				resolved := resolveEntity(*entity, siren.Href(res.Request.URL.String()))
+				herr.Entity = &resolved
+				if p, ok := siren.ParseProblem(resolved); ok {
+					herr.Problem = p
+				}
+			}
+		} else if p, ok := decodeProblem(res, body); ok {
+			herr.Problem = p
+		}
+		return nil, herr
+	}
+
+	response := &Response{
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+		URL:        res.Request.URL,
+	}
+
+	if res.StatusCode == http.StatusNoContent || len(body) == 0 {
+		return response, nil
+	}
+
+	decode, ok := c.decoder(res.Header.Get("content-type"))
+	if !ok {
+		return nil, ErrInvalidMediaType
+	}
 
-	return c.entity(req)
+	entity, err := decode(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := resolveEntity(*entity, siren.Href(response.URL.String()))
+	response.Entity = &resolved
+	return response, nil
 }
 
+// data merges the user data over the field defaults of the action. Empty
+// defaults are left out of form encodings, where they would otherwise be sent as
+// empty strings, but are kept for other types such as JSON.
 func (c *Client) data(action siren.Action, userData map[string]any) map[string]any {
 	data := make(map[string]any)
+	form := isFormEncoded(action)
 
 	for _, field := range action.Fields {
-		data[field.Name] = field.Value
+		if !form || !isEmpty(field.Value) {
+			data[field.Name] = field.Value
+		}
 	}
 
 	for key, value := range userData {
@@ -91,36 +311,37 @@ func (c *Client) data(action siren.Action, userData map[string]any) map[string]a
 	return data
 }
 
-func (c *Client) entity(req *http.Request) (*siren.Entity, error) {
-	req.Header.Set("accept", siren.MediaType)
-
-	res, err := c.http.Do(req)
+// resolve parses the given href, resolving it against the base URL when one has
+// been configured.
+func (c *Client) resolve(href string) (*url.URL, error) {
+	u, err := url.Parse(href)
 	if err != nil {
 		return nil, err
-	} else if res.Header.Get("content-type") != siren.MediaType {
-		return nil, ErrInvalidMediaType
+	} else if c.baseURL == "" {
+		return u, nil
 	}
 
-	var entity siren.Entity
-	d := json.NewDecoder(res.Body)
-	if err := d.Decode(&entity); err != nil {
-		return nil, ErrInvalidSirenEntity
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
 	}
-	return &entity, nil
+	return base.ResolveReference(u), nil
 }
 
-func encodeForm(data map[string]any) (io.Reader, error) {
-	q := url.Values{}
-	for key, value := range data {
-		q.Set(key, fmt.Sprintf("%v", value))
+// request creates a new request for the given href.
+func (c *Client) request(ctx context.Context, method, href string, body io.Reader) (*http.Request, error) {
+	u, err := c.resolve(href)
+	if err != nil {
+		return nil, err
 	}
-	return strings.NewReader(q.Encode()), nil
+
+	return http.NewRequestWithContext(ctx, method, u.String(), body)
 }
 
-func encodeJSON(data map[string]any) (io.Reader, error) {
-	b, err := json.Marshal(data)
+// entity unwraps the entity from the result of one of the Do methods.
+func entity(res *Response, err error) (*siren.Entity, error) {
 	if err != nil {
 		return nil, err
 	}
-	return bytes.NewBuffer(b), nil
+	return res.Entity, nil
 }
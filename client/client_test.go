@@ -1,10 +1,15 @@
 package client_test
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	siren "github.com/dominicbarnes/go-siren"
 	. "github.com/dominicbarnes/go-siren/client"
@@ -285,3 +290,763 @@ func (suite *ClientTestSuite) TestSubmitDefaultDataWithUserData() {
 	suite.NoError(err)
 	suite.EqualValues(entity, new(siren.Entity))
 }
+
+func (suite *ClientTestSuite) TestGetWithOptions() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// assert expected request was sent
+		suite.Equal(http.MethodGet, r.Method)
+		suite.Equal("/v2/entity", r.URL.Path)
+		suite.Equal(siren.MediaType, r.Header.Get("accept"))
+		suite.Equal("bar", r.Header.Get("x-foo"))
+		suite.Equal("test-agent/1.0", r.Header.Get("user-agent"))
+		suite.Equal("Bearer secret", r.Header.Get("authorization"))
+
+		// send a valid response for the client
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{}`))
+	}))
+
+	client := New(
+		WithBaseURL(ts.URL+"/v2/"),
+		WithHeader("x-foo", "bar"),
+		WithUserAgent("test-agent/1.0"),
+		WithBearerToken("secret"),
+	)
+
+	entity, err := client.Get("entity")
+	suite.NoError(err)
+	suite.EqualValues(entity, new(siren.Entity))
+}
+
+func (suite *ClientTestSuite) TestGetWithBasicAuth() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// assert expected request was sent
+		username, password, ok := r.BasicAuth()
+		suite.True(ok)
+		suite.Equal("user", username)
+		suite.Equal("pass", password)
+
+		// send a valid response for the client
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{}`))
+	}))
+
+	client := New(WithBasicAuth("user", "pass"))
+
+	entity, err := client.Get(ts.URL)
+	suite.NoError(err)
+	suite.EqualValues(entity, new(siren.Entity))
+}
+
+func (suite *ClientTestSuite) TestGetWithTimeout() {
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// hold the response until the client gives up
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	client := New(WithTimeout(10 * time.Millisecond))
+
+	entity, err := client.Get(ts.URL)
+	suite.ErrorIs(err, context.DeadlineExceeded)
+	suite.Nil(entity)
+}
+
+func (suite *ClientTestSuite) TestGetHTTPError() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// send an error response with a siren body
+		w.Header().Set("content-type", siren.MediaType)
+		w.Header().Set("x-request-id", "abc123")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"class":["error"],"title":"Not Found"}`))
+	}))
+
+	entity, err := suite.client.Get(ts.URL)
+	suite.Nil(entity)
+
+	var herr *HTTPError
+	suite.Require().ErrorAs(err, &herr)
+	suite.Equal(http.StatusNotFound, herr.StatusCode)
+	suite.Equal("abc123", herr.Header.Get("x-request-id"))
+	suite.JSONEq(`{"class":["error"],"title":"Not Found"}`, string(herr.Body))
+	suite.EqualValues(&siren.Entity{Class: siren.Classes{"error"}, Title: "Not Found"}, herr.Entity)
+	suite.EqualError(err, "unexpected status: 404 Not Found")
+}
+
+func (suite *ClientTestSuite) TestGetHTTPErrorNotSiren() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// send an error response with a plain body
+		w.Header().Set("content-type", "text/plain")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("oops"))
+	}))
+
+	entity, err := suite.client.Get(ts.URL)
+	suite.Nil(entity)
+
+	var herr *HTTPError
+	suite.Require().ErrorAs(err, &herr)
+	suite.Equal(http.StatusInternalServerError, herr.StatusCode)
+	suite.Equal("oops", string(herr.Body))
+	suite.Nil(herr.Entity)
+}
+
+func (suite *ClientTestSuite) TestGetHTTPErrorProblem() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// send an error entity describing the problem
+		w.Header().Set("content-type", siren.MediaType)
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{
+			"class": ["error"],
+			"title": "Invalid Order",
+			"properties": {
+				"status": 422,
+				"code": "invalid_order",
+				"message": "the order could not be placed",
+				"errors": [{"field": "quantity", "message": "must be positive"}]
+			},
+			"links": [
+				{"rel": ["self"], "href": "/orders/42"},
+				{"rel": ["help"], "href": "/docs/errors/invalid-order"}
+			]
+		}`))
+	}))
+
+	_, err := suite.client.Get(ts.URL)
+
+	var p *siren.Problem
+	suite.Require().ErrorAs(err, &p)
+	suite.Equal(&siren.Problem{
+		Type:     siren.Href(ts.URL + "/docs/errors/invalid-order"),
+		Title:    "Invalid Order",
+		Status:   http.StatusUnprocessableEntity,
+		Detail:   "the order could not be placed",
+		Instance: siren.Href(ts.URL + "/orders/42"),
+		Code:     "invalid_order",
+		Errors:   []siren.FieldProblem{{Field: "quantity", Message: "must be positive"}},
+	}, p)
+	suite.EqualError(err, "unexpected status: 422 Unprocessable Entity: the order could not be placed")
+}
+
+func (suite *ClientTestSuite) TestGetHTTPErrorProblemJSON() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// send RFC 7807 problem details
+		w.Header().Set("content-type", siren.ProblemMediaType)
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{
+			"type": "/docs/errors/out-of-credit",
+			"title": "You do not have enough credit.",
+			"status": 403,
+			"detail": "Your current balance is 30, but that costs 50.",
+			"instance": "/account/12345/msgs/abc"
+		}`))
+	}))
+
+	_, err := suite.client.Get(ts.URL)
+
+	var herr *HTTPError
+	suite.Require().ErrorAs(err, &herr)
+	suite.Nil(herr.Entity)
+	suite.Equal(&siren.Problem{
+		Type:     siren.Href(ts.URL + "/docs/errors/out-of-credit"),
+		Title:    "You do not have enough credit.",
+		Status:   http.StatusForbidden,
+		Detail:   "Your current balance is 30, but that costs 50.",
+		Instance: siren.Href(ts.URL + "/account/12345/msgs/abc"),
+	}, herr.Problem)
+
+	var p *siren.Problem
+	suite.Require().ErrorAs(err, &p)
+	suite.Same(herr.Problem, p)
+}
+
+func (suite *ClientTestSuite) TestGetContextCanceled() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.Fail("request should not have been sent")
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	entity, err := suite.client.GetContext(ctx, ts.URL)
+	suite.ErrorIs(err, context.Canceled)
+	suite.Nil(entity)
+}
+
+func (suite *ClientTestSuite) TestFollowContext() {
+	type key struct{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// send a valid response for the client
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{}`))
+	}))
+
+	var seen any
+	client := New(WithAuth(AuthProviderFunc(func(req *http.Request) error {
+		seen = req.Context().Value(key{})
+		return nil
+	})))
+
+	ctx := context.WithValue(context.Background(), key{}, "trace-id")
+	entity, err := client.FollowContext(ctx, siren.Link{
+		Href: siren.Href(ts.URL),
+		Rel:  siren.Rels{"self"},
+	})
+	suite.NoError(err)
+	suite.EqualValues(entity, new(siren.Entity))
+	suite.Equal("trace-id", seen)
+}
+
+func (suite *ClientTestSuite) TestSubmitContextDeadline() {
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// hold the response until the client gives up
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	entity, err := suite.client.SubmitContext(ctx, siren.Action{
+		Name:   "do-stuff",
+		Method: http.MethodPost,
+		Href:   siren.Href(ts.URL),
+	}, nil)
+	suite.ErrorIs(err, context.DeadlineExceeded)
+	suite.Nil(entity)
+}
+
+func (suite *ClientTestSuite) TestDoSubmitCreated() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// send a created response with a location
+		w.Header().Set("content-type", siren.MediaType)
+		w.Header().Set("location", "/orders/42")
+		w.Header().Set("etag", `"v1"`)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"title":"Order 42"}`))
+	}))
+
+	res, err := suite.client.DoSubmit(context.Background(), siren.Action{
+		Name:   "create-order",
+		Method: http.MethodPost,
+		Href:   siren.Href(ts.URL + "/orders"),
+	}, nil)
+	suite.Require().NoError(err)
+	suite.Equal(http.StatusCreated, res.StatusCode)
+	suite.Equal(`"v1"`, res.ETag())
+	suite.EqualValues(&siren.Entity{Title: "Order 42"}, res.Entity)
+	suite.Equal(ts.URL+"/orders", res.URL.String())
+
+	loc, err := res.Location()
+	suite.NoError(err)
+	suite.Equal(ts.URL+"/orders/42", loc.String())
+}
+
+func (suite *ClientTestSuite) TestDoGetRedirect() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{}`))
+	})
+	ts := httptest.NewServer(mux)
+
+	res, err := suite.client.DoGet(context.Background(), ts.URL+"/old")
+	suite.Require().NoError(err)
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Equal(ts.URL+"/new", res.URL.String())
+
+	_, err = res.Location()
+	suite.ErrorIs(err, http.ErrNoLocation)
+}
+
+func (suite *ClientTestSuite) TestDoNoContent() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	res, err := suite.client.DoFollow(context.Background(), siren.Link{
+		Href: siren.Href(ts.URL),
+		Rel:  siren.Rels{"self"},
+	})
+	suite.Require().NoError(err)
+	suite.Equal(http.StatusNoContent, res.StatusCode)
+	suite.Nil(res.Entity)
+}
+
+func (suite *ClientTestSuite) TestDoClosesBody() {
+	body := &closeRecorder{Reader: strings.NewReader(`{}`)}
+	client := New(WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {siren.MediaType}},
+				Body:       body,
+				Request:    req,
+			}, nil
+		}),
+	}))
+
+	req, err := http.NewRequest(http.MethodGet, "http://api.example.com/", nil)
+	suite.Require().NoError(err)
+
+	res, err := client.Do(req)
+	suite.Require().NoError(err)
+	suite.EqualValues(new(siren.Entity), res.Entity)
+	suite.True(body.closed)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (suite *ClientTestSuite) TestGetMediaTypeParameters() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// send a valid response with a charset parameter
+		w.Header().Set("content-type", siren.MediaType+"; charset=utf-8")
+		w.Write([]byte(`{}`))
+	}))
+
+	entity, err := suite.client.Get(ts.URL)
+	suite.NoError(err)
+	suite.EqualValues(entity, new(siren.Entity))
+}
+
+func (suite *ClientTestSuite) TestGetWithMediaType() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// assert expected request was sent
+		suite.Equal(siren.MediaType+", application/json;q=0.9", r.Header.Get("accept"))
+
+		// send a legacy json response
+		w.Header().Set("content-type", "application/json; charset=utf-8")
+		w.Write([]byte(`{"title":"legacy"}`))
+	}))
+
+	client := New(WithMediaType("application/json", nil))
+
+	entity, err := client.Get(ts.URL)
+	suite.NoError(err)
+	suite.EqualValues(&siren.Entity{Title: "legacy"}, entity)
+}
+
+func (suite *ClientTestSuite) TestGetWithMediaTypeDecoder() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// send a response in a custom format
+		w.Header().Set("content-type", "text/plain")
+		w.Write([]byte("hello world"))
+	}))
+
+	client := New(WithMediaType("text/plain", func(r io.Reader) (*siren.Entity, error) {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return &siren.Entity{Title: string(b)}, nil
+	}))
+
+	entity, err := client.Get(ts.URL)
+	suite.NoError(err)
+	suite.EqualValues(&siren.Entity{Title: "hello world"}, entity)
+}
+
+func (suite *ClientTestSuite) TestSubmitWithEncoder() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// assert expected request was sent
+		suite.Equal("application/x-custom; v=1", r.Header.Get("content-type"))
+		body, err := ioutil.ReadAll(r.Body)
+		suite.NoError(err)
+		suite.EqualValues("custom:bar", body)
+
+		// send a valid response for the client
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{}`))
+	}))
+
+	client := New(WithEncoder("application/x-custom", func(action siren.Action, data map[string]any) (io.Reader, string, error) {
+		return strings.NewReader(fmt.Sprintf("custom:%v", data["foo"])), "application/x-custom; v=1", nil
+	}))
+
+	entity, err := client.Submit(siren.Action{
+		Name:   "do-stuff",
+		Method: http.MethodPost,
+		Href:   siren.Href(ts.URL),
+		Type:   "application/x-custom",
+	}, map[string]any{"foo": "bar"})
+	suite.NoError(err)
+	suite.EqualValues(entity, new(siren.Entity))
+}
+
+func (suite *ClientTestSuite) TestSubmitUnsupportedType() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.Fail("request should not have been sent")
+	}))
+
+	entity, err := suite.client.Submit(siren.Action{
+		Name:   "do-stuff",
+		Method: http.MethodPost,
+		Href:   siren.Href(ts.URL),
+		Type:   "application/x-unknown",
+	}, map[string]any{"foo": "bar"})
+	suite.ErrorIs(err, ErrUnsupportedActionType)
+	suite.EqualError(err, "unsupported action type: application/x-unknown")
+	suite.Nil(entity)
+}
+
+func (suite *ClientTestSuite) TestSubmitMultipart() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// assert expected request was sent
+		suite.Equal(http.MethodPost, r.Method)
+		suite.NoError(r.ParseMultipartForm(1 << 20))
+		suite.Equal("Quarterly", r.FormValue("title"))
+
+		f, fh, err := r.FormFile("document")
+		if !suite.NoError(err) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer f.Close()
+		suite.Equal("report.txt", fh.Filename)
+		suite.Equal("text/plain", fh.Header.Get("content-type"))
+		body, err := ioutil.ReadAll(f)
+		suite.NoError(err)
+		suite.EqualValues("numbers go up", body)
+
+		// send a valid response for the client
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{}`))
+	}))
+
+	entity, err := suite.client.Submit(siren.Action{
+		Name:   "upload-report",
+		Method: http.MethodPost,
+		Href:   siren.Href(ts.URL),
+		Type:   "multipart/form-data",
+		Fields: []siren.ActionField{
+			{Name: "title", Type: "text"},
+			{Name: "document", Type: "file"},
+		},
+	}, map[string]any{
+		"title": "Quarterly",
+		"document": File{
+			Name:        "report.txt",
+			ContentType: "text/plain",
+			Reader:      strings.NewReader("numbers go up"),
+		},
+	})
+	suite.NoError(err)
+	suite.EqualValues(entity, new(siren.Entity))
+}
+
+func (suite *ClientTestSuite) TestSubmitGetQueryMultiValue() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// assert expected request was sent
+		suite.Equal("active=true&status=open&status=pending", r.URL.Query().Encode())
+
+		// send a valid response for the client
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{}`))
+	}))
+
+	entity, err := suite.client.Submit(siren.Action{
+		Name: "search",
+		Href: siren.Href(ts.URL),
+		Fields: []siren.ActionField{
+			{Name: "status", Type: "select"},
+			{Name: "active", Type: "checkbox"},
+			{Name: "q", Type: "search", Value: ""},
+		},
+	}, map[string]any{
+		"status": []string{"open", "pending"},
+		"active": true,
+	})
+	suite.NoError(err)
+	suite.EqualValues(entity, new(siren.Entity))
+}
+
+func (suite *ClientTestSuite) TestSubmitPostFormBracketNotation() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// assert expected request was sent
+		body, err := ioutil.ReadAll(r.Body)
+		suite.NoError(err)
+		suite.EqualValues("address%5Bcity%5D=Paris&address%5Btags%5D=a&address%5Btags%5D=b&name=Jo", body)
+
+		// send a valid response for the client
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{}`))
+	}))
+
+	client := New(WithBracketNotation())
+
+	entity, err := client.Submit(siren.Action{
+		Name:   "do-stuff",
+		Method: http.MethodPost,
+		Href:   siren.Href(ts.URL),
+	}, map[string]any{
+		"name": "Jo",
+		"address": map[string]any{
+			"city": "Paris",
+			"tags": []string{"a", "b"},
+		},
+	})
+	suite.NoError(err)
+	suite.EqualValues(entity, new(siren.Entity))
+}
+
+func (suite *ClientTestSuite) TestSubmitBracketNotationWithEncoder() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// assert expected request was sent
+		body, err := ioutil.ReadAll(r.Body)
+		suite.NoError(err)
+		suite.EqualValues("custom:bar", body)
+
+		// send a valid response for the client
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{}`))
+	}))
+
+	client := New(
+		WithEncoder("application/x-www-form-urlencoded", func(action siren.Action, data map[string]any) (io.Reader, string, error) {
+			return strings.NewReader(fmt.Sprintf("custom:%v", data["foo"])), "application/x-www-form-urlencoded", nil
+		}),
+		WithBracketNotation(),
+	)
+
+	entity, err := client.Submit(siren.Action{
+		Name:   "do-stuff",
+		Method: http.MethodPost,
+		Href:   siren.Href(ts.URL),
+	}, map[string]any{"foo": "bar"})
+	suite.NoError(err)
+	suite.EqualValues(entity, new(siren.Entity))
+}
+
+func (suite *ClientTestSuite) TestSubmitJSONEmptyDefaults() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// assert expected request was sent
+		body, err := ioutil.ReadAll(r.Body)
+		suite.NoError(err)
+		suite.JSONEq(`{"note":"","tags":null,"quantity":1}`, string(body))
+
+		// send a valid response for the client
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{}`))
+	}))
+
+	entity, err := suite.client.Submit(siren.Action{
+		Name:   "add-item",
+		Method: http.MethodPost,
+		Href:   siren.Href(ts.URL),
+		Type:   "application/json",
+		Fields: []siren.ActionField{
+			{Name: "note", Type: "text", Value: ""},
+			{Name: "tags", Type: "text"},
+			{Name: "quantity", Type: "number"},
+		},
+	}, map[string]any{"quantity": 1})
+	suite.NoError(err)
+	suite.EqualValues(entity, new(siren.Entity))
+}
+
+func (suite *ClientTestSuite) TestSubmitWithValidation() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.Fail("request should not have been sent")
+	}))
+
+	client := New(WithValidation())
+
+	entity, err := client.Submit(siren.Action{
+		Name:   "do-stuff",
+		Method: http.MethodPost,
+		Href:   siren.Href(ts.URL),
+		Fields: []siren.ActionField{
+			{Name: "quantity", Type: "number"},
+		},
+	}, map[string]any{"quantity": "many"})
+	suite.ErrorIs(err, ErrInvalidFieldValue)
+	suite.Nil(entity)
+}
+
+func (suite *ClientTestSuite) TestSubmitJSONWithCoercion() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// assert expected request was sent
+		body, err := ioutil.ReadAll(r.Body)
+		suite.NoError(err)
+		suite.JSONEq(`{"orderNumber":42,"quantity":3,"gift":true}`, string(body))
+
+		// send a valid response for the client
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{}`))
+	}))
+
+	client := New(WithCoercion())
+
+	entity, err := client.Submit(siren.Action{
+		Name:   "add-item",
+		Method: http.MethodPost,
+		Href:   siren.Href(ts.URL),
+		Type:   "application/json",
+		Fields: []siren.ActionField{
+			{Name: "orderNumber", Type: "hidden", Value: 42},
+			{Name: "quantity", Type: "number"},
+			{Name: "gift", Type: "checkbox"},
+		},
+	}, map[string]any{"quantity": "3", "gift": "on"})
+	suite.NoError(err)
+	suite.EqualValues(entity, new(siren.Entity))
+}
+
+func (suite *ClientTestSuite) TestGetResolvesRelativeHrefs() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a/b/c", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{
+			"links": [
+				{"rel": ["sibling"], "href": "./d"},
+				{"rel": ["parent"], "href": "../e"},
+				{"rel": ["next"], "href": "?page=2"},
+				{"rel": ["section"], "href": "#top"},
+				{"rel": ["root"], "href": "/f"},
+				{"rel": ["external"], "href": "https://example.com/g"}
+			],
+			"actions": [{"name": "update", "href": "c/edit"}],
+			"entities": [
+				{"rel": ["item"], "href": "items/1"},
+				{"rel": ["owner"], "links": [{"rel": ["self"], "href": "../../users/1"}]}
+			]
+		}`))
+	})
+	mux.HandleFunc("/a/b/d", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{"title":"sibling"}`))
+	})
+	ts := httptest.NewServer(mux)
+
+	entity, err := suite.client.Get(ts.URL + "/a/b/c")
+	suite.Require().NoError(err)
+
+	hrefs := make(map[string]siren.Href)
+	for _, link := range entity.Links {
+		hrefs[string(link.Rel[0])] = link.Href
+	}
+	suite.Equal(map[string]siren.Href{
+		"sibling":  siren.Href(ts.URL + "/a/b/d"),
+		"parent":   siren.Href(ts.URL + "/a/e"),
+		"next":     siren.Href(ts.URL + "/a/b/c?page=2"),
+		"section":  siren.Href(ts.URL + "/a/b/c#top"),
+		"root":     siren.Href(ts.URL + "/f"),
+		"external": "https://example.com/g",
+	}, hrefs)
+	suite.Equal(siren.Href(ts.URL+"/a/b/c/edit"), entity.Actions[0].Href)
+	suite.Equal(siren.Href(ts.URL+"/a/b/items/1"), entity.Entities[0].Href)
+	suite.Equal(siren.Href(ts.URL+"/users/1"), entity.Entities[1].Links[0].Href)
+
+	sibling, err := suite.client.Follow(entity.Links[0])
+	suite.NoError(err)
+	suite.Equal("sibling", sibling.Title)
+}
+
+func (suite *ClientTestSuite) TestFollowEmbedded() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// assert expected request was sent
+		suite.Equal("/items/1", r.URL.Path)
+
+		// send a valid response for the client
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{"title":"Item 1"}`))
+	}))
+
+	entity, err := suite.client.FollowEmbedded(siren.EmbeddedEntity{
+		Rel:  siren.Rels{"item"},
+		Href: siren.Href(ts.URL + "/items/1"),
+	})
+	suite.NoError(err)
+	suite.Equal("Item 1", entity.Title)
+}
+
+func (suite *ClientTestSuite) TestFollowTemplate() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// assert expected request was sent
+		suite.Equal("/orders", r.URL.Path)
+		suite.Equal("page=2&status=open", r.URL.Query().Encode())
+
+		// send a valid response for the client
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{}`))
+	}))
+
+	entity, err := suite.client.FollowTemplate(siren.Link{
+		Href: siren.Href(ts.URL + "/orders{?status,page}"),
+		Rel:  siren.Rels{"search"},
+	}, map[string]any{"status": "open", "page": 2})
+	suite.NoError(err)
+	suite.EqualValues(entity, new(siren.Entity))
+}
+
+func (suite *ClientTestSuite) TestFollowTemplateFromResponse() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", siren.MediaType)
+
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte(`{"links":[{"rel":["search"],"href":"/orders{?status,page}"},{"rel":["self"],"href":"/"}]}`))
+		case "/orders":
+			suite.Equal("page=2&status=open", r.URL.Query().Encode())
+			w.Write([]byte(`{"title":"Orders"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := New(WithBaseURL(ts.URL))
+
+	root, err := client.Get("/")
+	suite.Require().NoError(err)
+
+	self, ok := root.LinkByRel("self")
+	suite.Require().True(ok)
+	suite.Equal(siren.Href(ts.URL+"/"), self.Href)
+
+	search, ok := root.LinkByRel("search")
+	suite.Require().True(ok)
+	suite.Equal(siren.Href("/orders{?status,page}"), search.Href)
+	suite.True(search.Href.IsTemplated())
+
+	entity, err := client.FollowTemplate(search, map[string]any{"status": "open", "page": 2})
+	suite.Require().NoError(err)
+	suite.Equal("Orders", entity.Title)
+}
+
+func (suite *ClientTestSuite) TestFollowTemplateInvalid() {
+	entity, err := suite.client.FollowTemplate(siren.Link{
+		Href: "/orders{?status",
+		Rel:  siren.Rels{"search"},
+	}, nil)
+	suite.ErrorIs(err, siren.ErrInvalidTemplate)
+	suite.Nil(entity)
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	siren "github.com/dominicbarnes/go-siren"
+)
+
+// CoerceData converts user data to the JSON types implied by the declared type
+// of each action field, returning a new map:
+//
+//   - number and range values become an int64 or float64
+//   - checkbox values become a bool ("on" and "off" are also accepted)
+//   - date values become an RFC 3339 full-date (YYYY-MM-DD)
+//   - datetime-local values become an RFC 3339 date-time, assuming UTC
+//
+// Values for other field types, and names that are not declared by the action,
+// are left untouched, as are nil values and nil pointers. (eg: a nil *time.Time)
+// Slices are converted element by element. When any value can not be converted,
+// a *ValidationError is returned.
+func CoerceData(action siren.Action, data map[string]any) (map[string]any, error) {
+	types := make(map[string]string, len(action.Fields))
+	for _, field := range action.Fields {
+		types[field.Name] = field.Type
+	}
+
+	var errs []*FieldError
+	coerced := make(map[string]any, len(data))
+	for _, key := range sortedKeys(data) {
+		value, err := coerceValue(types[key], data[key])
+		if err != nil {
+			errs = append(errs, &FieldError{
+				Name:  key,
+				Type:  types[key],
+				Value: data[key],
+				Err:   err,
+			})
+			continue
+		}
+		coerced[key] = value
+	}
+
+	if len(errs) > 0 {
+		return nil, &ValidationError{Fields: errs}
+	}
+	return coerced, nil
+}
+
+func coerceValue(fieldType string, value any) (any, error) {
+	var coerce func(any) (any, error)
+	switch fieldType {
+	case "number", "range":
+		coerce = coerceNumber
+	case "checkbox":
+		coerce = coerceBool
+	case "date":
+		coerce = coerceDate
+	case "datetime-local":
+		coerce = coerceDateTime
+	default:
+		return value, nil
+	}
+
+	if isNil(value) {
+		return nil, nil
+	}
+
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice && !isScalar(value) {
+		values := make([]any, rv.Len())
+		for i := range values {
+			elem := rv.Index(i).Interface()
+			if isNil(elem) {
+				continue
+			}
+			v, err := coerce(elem)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = v
+		}
+		return values, nil
+	}
+
+	return coerce(value)
+}
+
+func coerceNumber(value any) (any, error) {
+	var s string
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v, nil
+	case json.Number:
+		s = v.String()
+	case string:
+		s = strings.TrimSpace(v)
+	default:
+		return nil, invalid(fmt.Sprintf("cannot convert %T to a number", value))
+	}
+
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i, nil
+	} else if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, nil
+	}
+	return nil, invalid(fmt.Sprintf("cannot convert %q to a number", s))
+}
+
+func coerceBool(value any) (any, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "on":
+			return true, nil
+		case "off", "":
+			return false, nil
+		}
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b, nil
+		}
+		return nil, invalid(fmt.Sprintf("cannot convert %q to a boolean", v))
+	}
+	return nil, invalid(fmt.Sprintf("cannot convert %T to a boolean", value))
+}
+
+func coerceDate(value any) (any, error) {
+	t, err := coerceTime(value, "2006-01-02", time.RFC3339)
+	if err != nil {
+		return nil, err
+	}
+	return t.Format("2006-01-02"), nil
+}
+
+func coerceDateTime(value any) (any, error) {
+	t, err := coerceTime(value, time.RFC3339, "2006-01-02T15:04", "2006-01-02T15:04:05")
+	if err != nil {
+		return nil, err
+	}
+	return t.Format(time.RFC3339), nil
+}
+
+func coerceTime(value any, layouts ...string) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		return *v, nil
+	case string:
+		for _, layout := range layouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, invalid(fmt.Sprintf("cannot convert %q to a date", v))
+	}
+	return time.Time{}, invalid(fmt.Sprintf("cannot convert %T to a date", value))
+}
+
+// isNil reports whether the value is nil or a nil pointer, which are treated as
+// absent rather than as values to convert. (eg: a nil *time.Time)
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
+
+// isJSON reports whether the given action type is application/json.
+func isJSON(actionType string) bool {
+	mediaType, _, err := mime.ParseMediaType(actionType)
+	return err == nil && mediaType == "application/json"
+}
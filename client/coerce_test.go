@@ -0,0 +1,107 @@
+package client_test
+
+import (
+	"testing"
+	"time"
+
+	siren "github.com/dominicbarnes/go-siren"
+	. "github.com/dominicbarnes/go-siren/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoerceData(t *testing.T) {
+	action := siren.Action{
+		Name: "add-item",
+		Href: "/orders/42/items",
+		Type: "application/json",
+		Fields: []siren.ActionField{
+			{Name: "quantity", Type: "number"},
+			{Name: "price", Type: "number"},
+			{Name: "ids", Type: "number"},
+			{Name: "gift", Type: "checkbox"},
+			{Name: "express", Type: "checkbox"},
+			{Name: "deliverOn", Type: "date"},
+			{Name: "remindAt", Type: "datetime-local"},
+			{Name: "note", Type: "text"},
+		},
+	}
+
+	actual, err := CoerceData(action, map[string]any{
+		"quantity":  "3",
+		"price":     "9.99",
+		"ids":       []string{"1", "2"},
+		"gift":      "on",
+		"express":   "false",
+		"deliverOn": time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		"remindAt":  "2020-01-02T09:30",
+		"note":      "42",
+		"extra":     "untouched",
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"quantity":  int64(3),
+		"price":     9.99,
+		"ids":       []any{int64(1), int64(2)},
+		"gift":      true,
+		"express":   false,
+		"deliverOn": "2020-01-02",
+		"remindAt":  "2020-01-02T09:30:00Z",
+		"note":      "42",
+		"extra":     "untouched",
+	}, actual)
+}
+
+func TestCoerceDataErrors(t *testing.T) {
+	action := siren.Action{
+		Name: "add-item",
+		Href: "/orders/42/items",
+		Type: "application/json",
+		Fields: []siren.ActionField{
+			{Name: "quantity", Type: "number"},
+			{Name: "gift", Type: "checkbox"},
+			{Name: "deliverOn", Type: "date"},
+		},
+	}
+
+	_, err := CoerceData(action, map[string]any{
+		"quantity":  "three",
+		"gift":      "maybe",
+		"deliverOn": "tomorrow",
+	})
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Fields, 3)
+	require.ErrorIs(t, err, ErrInvalidFieldValue)
+	require.EqualError(t, err, `validation failed: field "deliverOn": invalid field value: cannot convert "tomorrow" to a date; field "gift": invalid field value: cannot convert "maybe" to a boolean; field "quantity": invalid field value: cannot convert "three" to a number`)
+}
+
+func TestCoerceDataNil(t *testing.T) {
+	action := siren.Action{
+		Name: "schedule",
+		Href: "/reminders",
+		Type: "application/json",
+		Fields: []siren.ActionField{
+			{Name: "deliverOn", Type: "date"},
+			{Name: "remindAt", Type: "datetime-local"},
+			{Name: "quantity", Type: "number"},
+			{Name: "dates", Type: "date"},
+		},
+	}
+
+	at := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	actual, err := CoerceData(action, map[string]any{
+		"deliverOn": (*time.Time)(nil),
+		"remindAt":  &at,
+		"quantity":  (*int)(nil),
+		"dates":     []*time.Time{&at, nil},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"deliverOn": nil,
+		"remindAt":  "2020-01-02T03:04:05Z",
+		"quantity":  nil,
+		"dates":     []any{"2020-01-02", nil},
+	}, actual)
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	siren "github.com/dominicbarnes/go-siren"
+)
+
+// Decoder decodes a response body into a siren entity.
+type Decoder func(r io.Reader) (*siren.Entity, error)
+
+// DecodeJSON is the default Decoder, which decodes a siren entity from JSON.
+// It can be used for other media types whose bodies are shaped like siren,
+// such as application/json from legacy gateways.
+func DecodeJSON(r io.Reader) (*siren.Entity, error) {
+	var entity siren.Entity
+	d := json.NewDecoder(r)
+	if err := d.Decode(&entity); err != nil {
+		return nil, ErrInvalidSirenEntity
+	}
+	return &entity, nil
+}
+
+// decoder finds the Decoder registered for the media type in the given
+// content-type header. Parameters such as charset are ignored.
+func (c *Client) decoder(contentType string) (Decoder, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+
+	d, ok := c.decoders[mediaType]
+	return d, ok
+}
+
+// accept builds the accept header for requests, preferring siren over any
+// other media types that have been configured.
+func (c *Client) accept() string {
+	if len(c.mediaTypes) == 0 {
+		return siren.MediaType
+	}
+
+	accept := []string{siren.MediaType}
+	for _, mediaType := range c.mediaTypes {
+		accept = append(accept, mediaType+";q=0.9")
+	}
+	return strings.Join(accept, ", ")
+}
+
+// decodeProblem decodes an application/problem+json response body, resolving
+// the type and instance against the response URL.
+func decodeProblem(res *http.Response, body []byte) (*siren.Problem, bool) {
+	mediaType, _, err := mime.ParseMediaType(res.Header.Get("content-type"))
+	if err != nil || mediaType != siren.ProblemMediaType {
+		return nil, false
+	}
+
+	var p siren.Problem
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, false
+	}
+
+	base := siren.Href(res.Request.URL.String())
+	if p.Type != "" {
+		if href, err := p.Type.Resolve(base); err == nil {
+			p.Type = href
+		}
+	}
+	if p.Instance != "" {
+		if href, err := p.Instance.Resolve(base); err == nil {
+			p.Instance = href
+		}
+	}
+
+	return &p, true
+}
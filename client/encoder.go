@@ -0,0 +1,237 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	siren "github.com/dominicbarnes/go-siren"
+)
+
+// Encoder encodes the data for an action into a request body. The content-type
+// header to send with the body is returned alongside it, which allows encoders
+// to add parameters. (eg: a multipart boundary)
+type Encoder func(action siren.Action, data map[string]any) (body io.Reader, contentType string, err error)
+
+// EncodeForm is the Encoder for application/x-www-form-urlencoded actions.
+// Values are converted to strings following the same rules used for query
+// strings: nil values are skipped, slices become repeated keys, booleans become
+// "true" or "false" and times are formatted as RFC 3339. Nested maps are
+// rejected, see WithBracketNotation.
+func EncodeForm(action siren.Action, data map[string]any) (io.Reader, string, error) {
+	return encodeForm(data, false)
+}
+
+func encodeForm(data map[string]any, brackets bool) (io.Reader, string, error) {
+	q, err := formValues(data, brackets)
+	if err != nil {
+		return nil, "", err
+	}
+	return strings.NewReader(q.Encode()), "application/x-www-form-urlencoded", nil
+}
+
+// EncodeJSON is the Encoder for application/json actions.
+func EncodeJSON(action siren.Action, data map[string]any) (io.Reader, string, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, "", err
+	}
+	return bytes.NewBuffer(b), "application/json", nil
+}
+
+// EncodeMultipart is the Encoder for multipart/form-data actions. Values that
+// are a File, *os.File or io.Reader (or a slice of them) are sent as file
+// parts, everything else is sent as regular form parts using the same rules as
+// EncodeForm.
+//
+// The body is streamed as it is read, so files are never buffered in memory.
+func EncodeMultipart(action siren.Action, data map[string]any) (io.Reader, string, error) {
+	return encodeMultipart(data, false)
+}
+
+func encodeMultipart(data map[string]any, brackets bool) (io.Reader, string, error) {
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(writeMultipart(w, data, brackets))
+	}()
+
+	return pr, w.FormDataContentType(), nil
+}
+
+func writeMultipart(w *multipart.Writer, data map[string]any, brackets bool) error {
+	for _, key := range sortedKeys(data) {
+		if files, ok := asFiles(key, data[key]); ok {
+			for _, f := range files {
+				if err := writeFile(w, key, f); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		q := url.Values{}
+		if err := addFormValue(q, key, data[key], brackets); err != nil {
+			return err
+		}
+		for _, name := range sortedKeys(q) {
+			for _, value := range q[name] {
+				if err := w.WriteField(name, value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return w.Close()
+}
+
+func writeFile(w *multipart.Writer, key string, f File) error {
+	if f.Reader == nil {
+		return fmt.Errorf("field %q: file has no reader", key)
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("content-disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(key), escapeQuotes(f.Name)))
+	h.Set("content-type", f.ContentType)
+
+	part, err := w.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, f.Reader)
+	return err
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// EncodeText is the Encoder for text/plain actions, which writes each field as
+// a "name=value" line as described by the HTML form submission algorithm.
+// Values are converted using the same rules as EncodeForm.
+func EncodeText(action siren.Action, data map[string]any) (io.Reader, string, error) {
+	return encodeText(data, false)
+}
+
+func encodeText(data map[string]any, brackets bool) (io.Reader, string, error) {
+	q, err := formValues(data, brackets)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	for _, key := range sortedKeys(q) {
+		for _, value := range q[key] {
+			fmt.Fprintf(&buf, "%s=%s\r\n", key, value)
+		}
+	}
+	return &buf, "text/plain; charset=utf-8", nil
+}
+
+// EncodeXML is the Encoder for application/xml and text/xml actions. The data
+// is written as child elements of a root element named after the action. Slices
+// become repeated elements and nested maps become nested elements.
+func EncodeXML(action siren.Action, data map[string]any) (io.Reader, string, error) {
+	root := action.Name
+	if root == "" {
+		root = "action"
+	}
+
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+
+	if err := writeXML(e, root, data); err != nil {
+		return nil, "", err
+	}
+	if err := e.Flush(); err != nil {
+		return nil, "", err
+	}
+	return &buf, "application/xml", nil
+}
+
+func writeXML(e *xml.Encoder, name string, value any) error {
+	if s, ok := formatValue(value); ok {
+		return e.EncodeElement(s, xml.StartElement{Name: xml.Name{Local: name}})
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := writeXML(e, name, rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("field %q: nested maps must have string keys", name)
+		}
+
+		start := xml.StartElement{Name: xml.Name{Local: name}}
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, k := range keys {
+			if err := writeXML(e, k.String(), rv.MapIndex(k).Interface()); err != nil {
+				return err
+			}
+		}
+
+		return e.EncodeToken(start.End())
+	}
+
+	return e.EncodeElement(fmt.Sprintf("%v", rv.Interface()), xml.StartElement{Name: xml.Name{Local: name}})
+}
+
+// encode finds the Encoder registered for the action's type and uses it to
+// build the request body.
+func (c *Client) encode(action siren.Action, data map[string]any) (io.Reader, string, error) {
+	mediaType, _, err := mime.ParseMediaType(action.GetType())
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedActionType, action.GetType())
+	}
+
+	encode, ok := c.encoders[mediaType]
+	if !ok {
+		return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedActionType, mediaType)
+	}
+
+	return encode(action, data)
+}
+
+func sortedKeys[V any](data map[string]V) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,207 @@
+package client_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	siren "github.com/dominicbarnes/go-siren"
+	. "github.com/dominicbarnes/go-siren/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeForm(t *testing.T) {
+	body, contentType, err := EncodeForm(siren.Action{}, map[string]any{"a": "b", "c": 1})
+	require.NoError(t, err)
+	require.Equal(t, "application/x-www-form-urlencoded", contentType)
+	require.Equal(t, "a=b&c=1", readString(t, body))
+}
+
+func TestEncodeFormValues(t *testing.T) {
+	type spec struct {
+		input    map[string]any
+		expected string
+	}
+
+	specs := map[string]spec{
+		"slice of strings": {
+			input:    map[string]any{"tags": []string{"a", "b"}},
+			expected: "tags=a&tags=b",
+		},
+		"slice of any": {
+			input:    map[string]any{"ids": []any{1, "two", 3.5}},
+			expected: "ids=1&ids=two&ids=3.5",
+		},
+		"nil": {
+			input:    map[string]any{"a": nil, "b": "c"},
+			expected: "b=c",
+		},
+		"nil pointer": {
+			input:    map[string]any{"a": (*string)(nil), "b": (*time.Time)(nil)},
+			expected: "",
+		},
+		"booleans": {
+			input:    map[string]any{"yes": true, "no": false},
+			expected: "no=false&yes=true",
+		},
+		"time": {
+			input:    map[string]any{"at": time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+			expected: "at=2020-01-02T03%3A04%3A05Z",
+		},
+		"large float": {
+			input:    map[string]any{"n": 1000000.0},
+			expected: "n=1000000",
+		},
+	}
+
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			body, _, err := EncodeForm(siren.Action{}, spec.input)
+			require.NoError(t, err)
+			require.Equal(t, spec.expected, readString(t, body))
+		})
+	}
+
+	t.Run("nested map", func(t *testing.T) {
+		_, _, err := EncodeForm(siren.Action{}, map[string]any{
+			"address": map[string]any{"city": "Paris"},
+		})
+		require.EqualError(t, err, `field "address": nested values require bracket notation`)
+	})
+}
+
+func TestEncodeJSON(t *testing.T) {
+	body, contentType, err := EncodeJSON(siren.Action{}, map[string]any{"a": "b", "c": 1})
+	require.NoError(t, err)
+	require.Equal(t, "application/json", contentType)
+	require.JSONEq(t, `{"a":"b","c":1}`, readString(t, body))
+}
+
+func TestEncodeMultipart(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "*.csv")
+	require.NoError(t, err)
+	_, err = f.WriteString("a,b,c")
+	require.NoError(t, err)
+	_, err = f.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	defer f.Close()
+
+	body, contentType, err := EncodeMultipart(siren.Action{}, map[string]any{
+		"name":   "report",
+		"upload": strings.NewReader("file contents"),
+		"avatar": File{Name: "me.png", ContentType: "image/png", Reader: strings.NewReader("png")},
+		"csv":    f,
+	})
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+	require.Equal(t, "multipart/form-data", mediaType)
+
+	form, err := multipart.NewReader(body, params["boundary"]).ReadForm(1 << 20)
+	require.NoError(t, err)
+	require.Equal(t, []string{"report"}, form.Value["name"])
+
+	type upload struct {
+		filename    string
+		contentType string
+		contents    string
+	}
+
+	files := map[string]upload{
+		"upload": {"upload", DefaultFileContentType, "file contents"},
+		"avatar": {"me.png", "image/png", "png"},
+		"csv":    {filepath.Base(f.Name()), DefaultFileContentType, "a,b,c"},
+	}
+
+	for key, expected := range files {
+		require.Len(t, form.File[key], 1, key)
+		fh := form.File[key][0]
+		require.Equal(t, expected.filename, fh.Filename, key)
+		require.Equal(t, expected.contentType, fh.Header.Get("content-type"), key)
+
+		r, err := fh.Open()
+		require.NoError(t, err)
+		require.Equal(t, expected.contents, readString(t, r), key)
+	}
+}
+
+func TestEncodeMultipartReadError(t *testing.T) {
+	body, _, err := EncodeMultipart(siren.Action{}, map[string]any{
+		"upload": iotest.ErrReader(errors.New("disk on fire")),
+	})
+	require.NoError(t, err)
+
+	_, err = io.ReadAll(body)
+	require.EqualError(t, err, "disk on fire")
+}
+
+func TestEncodeMultipartNilReader(t *testing.T) {
+	specs := map[string]any{
+		"file":          File{Name: "a.txt"},
+		"file pointer":  &File{Name: "a.txt"},
+		"typed nil":     (*bytes.Buffer)(nil),
+		"nil os.File":   (*os.File)(nil),
+		"file in slice": []File{{Name: "a.txt", Reader: strings.NewReader("a")}, {Name: "b.txt"}},
+	}
+
+	for name, value := range specs {
+		t.Run(name, func(t *testing.T) {
+			body, _, err := EncodeMultipart(siren.Action{}, map[string]any{"upload": value})
+			require.NoError(t, err)
+
+			_, err = io.ReadAll(body)
+			require.EqualError(t, err, `field "upload": file has no reader`)
+		})
+	}
+}
+
+func TestEncodeText(t *testing.T) {
+	body, contentType, err := EncodeText(siren.Action{}, map[string]any{"b": 2, "a": "one"})
+	require.NoError(t, err)
+	require.Equal(t, "text/plain; charset=utf-8", contentType)
+	require.Equal(t, "a=one\r\nb=2\r\n", readString(t, body))
+
+	body, _, err = EncodeText(siren.Action{}, map[string]any{"tags": []string{"x", "y"}, "skip": nil})
+	require.NoError(t, err)
+	require.Equal(t, "tags=x\r\ntags=y\r\n", readString(t, body))
+}
+
+func TestEncodeXML(t *testing.T) {
+	t.Run("named action", func(t *testing.T) {
+		body, contentType, err := EncodeXML(siren.Action{Name: "add-item"}, map[string]any{"quantity": 3, "code": "a&b"})
+		require.NoError(t, err)
+		require.Equal(t, "application/xml", contentType)
+		require.Equal(t, "<add-item><code>a&amp;b</code><quantity>3</quantity></add-item>", readString(t, body))
+	})
+
+	t.Run("nested values", func(t *testing.T) {
+		body, _, err := EncodeXML(siren.Action{Name: "order"}, map[string]any{
+			"tags":    []string{"a", "b"},
+			"address": map[string]any{"city": "Paris", "zip": nil},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "<order><address><city>Paris</city></address><tags>a</tags><tags>b</tags></order>", readString(t, body))
+	})
+
+	t.Run("unnamed action", func(t *testing.T) {
+		body, _, err := EncodeXML(siren.Action{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, "<action></action>", readString(t, body))
+	})
+}
+
+func readString(t *testing.T, r io.Reader) string {
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(b)
+}
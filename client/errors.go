@@ -1,6 +1,12 @@
 package client
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	siren "github.com/dominicbarnes/go-siren"
+)
 
 var (
 	// ErrInvalidMediaType is used when an incorrect media type is detected by the client.
@@ -8,4 +14,39 @@ var (
 
 	// ErrInvalidSirenEntity is used when a response body can not be decoded as a siren entity.
 	ErrInvalidSirenEntity = errors.New("invalid siren entity")
+
+	// ErrUnsupportedActionType is used when there is no encoder registered for the type of an action.
+	ErrUnsupportedActionType = errors.New("unsupported action type")
 )
+
+// HTTPError is used when the server responds with a status code outside of the
+// 2xx range. When the response body is a siren entity, it is decoded and made
+// available as Entity.
+//
+// When the body describes a problem, either as an entity with the "error" class
+// or as application/problem+json, it is made available as Problem and can also
+// be retrieved using errors.As.
+type HTTPError struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Entity     *siren.Entity
+	Problem    *siren.Problem
+}
+
+// Error implements the error interface.
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("unexpected status: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Problem != nil && e.Problem.Detail != "" {
+		msg += ": " + e.Problem.Detail
+	}
+	return msg
+}
+
+// Unwrap returns the Problem, if any.
+func (e *HTTPError) Unwrap() error {
+	if e.Problem == nil {
+		return nil
+	}
+	return e.Problem
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+)
+
+// DefaultFileContentType is the content type used for file uploads when one is
+// not specified.
+const DefaultFileContentType = "application/octet-stream"
+
+// File is a file to upload when submitting a multipart/form-data action.
+type File struct {
+	// Name is the filename sent to the server. When empty, the name of the
+	// field is used instead.
+	Name string
+
+	// ContentType is the media type of the file contents. When empty,
+	// DefaultFileContentType is used.
+	ContentType string
+
+	// Reader supplies the file contents.
+	Reader io.Reader
+}
+
+// asFile converts a value from user data into a File, returning false when the
+// value should be treated as a regular form value instead.
+func asFile(key string, value any) (File, bool) {
+	var f File
+	switch v := value.(type) {
+	case File:
+		f = v
+	case *File:
+		if v == nil {
+			return f, false
+		}
+		f = *v
+	case *os.File:
+		if v == nil {
+			// an upload without contents, which is reported by writeFile
+			f = File{}
+		} else {
+			f = File{Name: filepath.Base(v.Name()), Reader: v}
+		}
+	case io.Reader:
+		f = File{Reader: v}
+	default:
+		return f, false
+	}
+
+	if isNilReader(f.Reader) {
+		f.Reader = nil
+	}
+	if f.Name == "" {
+		f.Name = key
+	}
+	if f.ContentType == "" {
+		f.ContentType = DefaultFileContentType
+	}
+	return f, true
+}
+
+// asFiles converts a value from user data into a list of files, which supports
+// a single file as well as a slice of files for multi-file fields.
+func asFiles(key string, value any) ([]File, bool) {
+	if f, ok := asFile(key, value); ok {
+		return []File{f}, true
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice || rv.Len() == 0 {
+		return nil, false
+	}
+
+	files := make([]File, rv.Len())
+	for i := range files {
+		f, ok := asFile(key, rv.Index(i).Interface())
+		if !ok {
+			return nil, false
+		}
+		files[i] = f
+	}
+	return files, true
+}
+
+// isNilReader reports whether the reader is nil, including typed nil pointers
+// such as a nil *bytes.Buffer.
+func isNilReader(r io.Reader) bool {
+	if r == nil {
+		return true
+	}
+	rv := reflect.ValueOf(r)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
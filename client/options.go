@@ -1,11 +1,132 @@
 package client
 
-import "net/http"
+import (
+	"net/http"
+	"strings"
+	"time"
+)
 
+// ClientOption is used to configure a Client when calling New.
 type ClientOption func(*Client)
 
+// WithHTTPClient sets the underlying HTTP client used to send requests.
 func WithHTTPClient(hc *http.Client) ClientOption {
 	return func(c *Client) {
 		c.http = hc
 	}
 }
+
+// WithBaseURL sets the URL that relative hrefs are resolved against before a
+// request is sent.
+func WithBaseURL(base string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = base
+	}
+}
+
+// WithHeader adds a default header that is sent with every request.
+func WithHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		c.header.Add(key, value)
+	}
+}
+
+// WithHeaders adds a set of default headers that are sent with every request.
+func WithHeaders(header http.Header) ClientOption {
+	return func(c *Client) {
+		for key, values := range header {
+			for _, value := range values {
+				c.header.Add(key, value)
+			}
+		}
+	}
+}
+
+// WithAuth sets the provider used to authenticate every request.
+func WithAuth(auth AuthProvider) ClientOption {
+	return func(c *Client) {
+		c.auth = auth
+	}
+}
+
+// WithBearerToken authenticates every request with the given bearer token.
+func WithBearerToken(token string) ClientOption {
+	return WithAuth(BearerToken(token))
+}
+
+// WithBasicAuth authenticates every request with the given username and
+// password.
+func WithBasicAuth(username, password string) ClientOption {
+	return WithAuth(BasicAuth(username, password))
+}
+
+// WithTimeout sets a timeout that applies to each request individually,
+// including reading the response body.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// WithMediaType allows the client to accept responses with the given media type
+// in addition to siren, decoding them with the supplied Decoder. When decoder
+// is nil, DecodeJSON is used.
+func WithMediaType(mediaType string, decoder Decoder) ClientOption {
+	return func(c *Client) {
+		if decoder == nil {
+			decoder = DecodeJSON
+		}
+
+		mediaType = strings.ToLower(mediaType)
+		if _, ok := c.decoders[mediaType]; !ok {
+			c.mediaTypes = append(c.mediaTypes, mediaType)
+		}
+		c.decoders[mediaType] = decoder
+	}
+}
+
+// WithEncoder registers an Encoder that is used when submitting actions with
+// the given media type. This can be used to support additional types or to
+// replace any of the built-in encoders.
+func WithEncoder(mediaType string, encoder Encoder) ClientOption {
+	return func(c *Client) {
+		c.encoders[strings.ToLower(mediaType)] = encoder
+	}
+}
+
+// WithBracketNotation allows nested maps in user data to be encoded in query
+// strings and form bodies using bracket notation. (eg: "address[city]=Paris")
+// Without this option, nested maps are rejected with an error.
+//
+// This applies to the built-in form, multipart and text encoders. Encoders
+// registered with WithEncoder are left untouched, whatever the order of the
+// options.
+func WithBracketNotation() ClientOption {
+	return func(c *Client) {
+		c.brackets = true
+	}
+}
+
+// WithValidation enables checking user data against the fields declared by an
+// action before it is submitted. See ValidateData for details.
+func WithValidation() ClientOption {
+	return func(c *Client) {
+		c.validate = true
+	}
+}
+
+// WithCoercion enables converting user data to the JSON types implied by the
+// fields of application/json actions before they are submitted. See CoerceData
+// for details.
+func WithCoercion() ClientOption {
+	return func(c *Client) {
+		c.coerce = true
+	}
+}
@@ -0,0 +1,30 @@
+package client
+
+import siren "github.com/dominicbarnes/go-siren"
+
+// resolveEntity returns a copy of the entity with every link, action and
+// sub-entity href resolved against the URL the entity was retrieved from,
+// following RFC 3986 reference resolution. Hrefs that can not be parsed are
+// left untouched so the error surfaces when they are used, and rels are never
+// changed.
+//
+// Templated hrefs are also left untouched, since resolving them would escape
+// the expressions. They are resolved once expanded, see FollowTemplate.
+func resolveEntity(e siren.Entity, base siren.Href) siren.Entity {
+	resolved, _ := e.MapHrefs(func(kind siren.HrefKind, h siren.Href) (siren.Href, error) {
+		switch kind {
+		case siren.HrefKindLinkRel, siren.HrefKindEmbeddedRel:
+			return h, nil
+		}
+
+		if h == "" || h.IsTemplated() {
+			return h, nil
+		}
+
+		if r, err := h.Resolve(base); err == nil {
+			return r, nil
+		}
+		return h, nil
+	})
+	return resolved
+}
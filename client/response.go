@@ -0,0 +1,47 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+
+	siren "github.com/dominicbarnes/go-siren"
+)
+
+// Response is a decoded response from a siren API, retaining the details of
+// the underlying HTTP response that are discarded by the simpler methods.
+type Response struct {
+	// Entity is the decoded siren entity, which is nil when the server did not
+	// send a body. (eg: 204 No Content)
+	Entity *siren.Entity
+
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Header contains the response headers.
+	Header http.Header
+
+	// URL is the final URL of the request, after any redirects were followed.
+	URL *url.URL
+}
+
+// Location returns the URL from the Location header, resolved against the
+// response URL. If there is no Location header, http.ErrNoLocation is returned.
+func (r *Response) Location() (*url.URL, error) {
+	loc := r.Header.Get("location")
+	if loc == "" {
+		return nil, http.ErrNoLocation
+	}
+
+	u, err := url.Parse(loc)
+	if err != nil {
+		return nil, err
+	} else if r.URL == nil {
+		return u, nil
+	}
+	return r.URL.ResolveReference(u), nil
+}
+
+// ETag returns the value of the ETag header.
+func (r *Response) ETag() string {
+	return r.Header.Get("etag")
+}
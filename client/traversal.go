@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	siren "github.com/dominicbarnes/go-siren"
+)
+
+var (
+	// ErrLinkNotFound is used during a traversal when an entity has no link or
+	// sub-entity with the requested rel.
+	ErrLinkNotFound = errors.New("link not found")
+
+	// ErrActionNotFound is used during a traversal when an entity has no action
+	// with the requested name.
+	ErrActionNotFound = errors.New("action not found")
+
+	// ErrNoEntity is used during a traversal when a step has no entity to work
+	// from, such as after a step that returned 204 No Content.
+	ErrNoEntity = errors.New("no entity")
+)
+
+// TraversalError is used when a step of a traversal fails. Since each step
+// depends on the previous one, only the first failure is reported.
+type TraversalError struct {
+	// Step is the position of the failed step in the traversal, starting at 1.
+	Step int
+
+	// Op is the kind of step that failed. (eg: "follow" or "submit")
+	Op string
+
+	// Target is the rel or action name that was requested.
+	Target string
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *TraversalError) Error() string {
+	return fmt.Sprintf("step %d (%s %q): %v", e.Step, e.Op, e.Target, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *TraversalError) Unwrap() error {
+	return e.Err
+}
+
+// Traversal navigates a siren API one step at a time, resolving each link or
+// action from the entity returned by the previous step. Errors do not need to
+// be checked between steps: once a step fails, the remaining steps are skipped
+// and the error is reported by Entity or Err.
+//
+//	order, err := c.From(root).
+//		Follow("orders").
+//		Follow("next").
+//		Submit("add-item", data).
+//		Entity()
+type Traversal struct {
+	client *Client
+	ctx    context.Context
+	entity *siren.Entity
+	step   int
+	err    error
+}
+
+// From starts a traversal at the given entity.
+func (c *Client) From(root *siren.Entity) *Traversal {
+	return &Traversal{
+		client: c,
+		ctx:    context.Background(),
+		entity: root,
+	}
+}
+
+// WithContext binds the requests for subsequent steps to the given context.
+func (t *Traversal) WithContext(ctx context.Context) *Traversal {
+	t.ctx = ctx
+	return t
+}
+
+// Follow moves to the entity behind the first link with the given rel. When
+// there is no such link, the sub-entities are searched instead: embedded links
+// are fetched, while embedded representations are used as-is.
+func (t *Traversal) Follow(rel siren.Href) *Traversal {
+	return t.do("follow", string(rel), func(e *siren.Entity) (*siren.Entity, error) {
+		if link, ok := e.LinkByRel(rel); ok {
+			return t.client.FollowContext(t.ctx, link)
+		}
+
+		for _, embed := range e.EntitiesByRel(rel) {
+			if embed.IsLink() {
+				return t.client.FollowEmbeddedContext(t.ctx, embed)
+			}
+
+			entity := embed.Entity
+			return &entity, nil
+		}
+
+		return nil, ErrLinkNotFound
+	})
+}
+
+// Submit moves to the entity returned by submitting the action with the given
+// name, using data supplied by the user.
+func (t *Traversal) Submit(name string, userData map[string]any) *Traversal {
+	return t.do("submit", name, func(e *siren.Entity) (*siren.Entity, error) {
+		action, ok := e.ActionByName(name)
+		if !ok {
+			return nil, ErrActionNotFound
+		}
+
+		return t.client.SubmitContext(t.ctx, action, userData)
+	})
+}
+
+// Entity returns the entity at the end of the traversal, or the error from the
+// step that failed.
+func (t *Traversal) Entity() (*siren.Entity, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return t.entity, nil
+}
+
+// Err returns the error from the step that failed, if any.
+func (t *Traversal) Err() error {
+	return t.err
+}
+
+func (t *Traversal) do(op, target string, fn func(*siren.Entity) (*siren.Entity, error)) *Traversal {
+	t.step++
+	if t.err != nil {
+		return t
+	}
+
+	var err error
+	if t.entity == nil && t.step == 1 {
+		err = fmt.Errorf("traversal started with %w", ErrNoEntity)
+	} else if t.entity == nil {
+		err = fmt.Errorf("step %d returned %w", t.step-1, ErrNoEntity)
+	} else {
+		t.entity, err = fn(t.entity)
+	}
+
+	if err != nil {
+		t.entity = nil
+		t.err = &TraversalError{
+			Step:   t.step,
+			Op:     op,
+			Target: target,
+			Err:    err,
+		}
+	}
+
+	return t
+}
@@ -0,0 +1,132 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	siren "github.com/dominicbarnes/go-siren"
+	. "github.com/dominicbarnes/go-siren/client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func traversalServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	respond := func(path, body string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("content-type", siren.MediaType)
+			w.Write([]byte(body))
+		})
+	}
+
+	respond("/orders", `{
+		"class": ["orders"],
+		"links": [{"rel": ["next"], "href": "/orders/page/2"}],
+		"entities": [
+			{"rel": ["item"], "href": "/orders/41"},
+			{"rel": ["latest"], "class": ["order"], "title": "Order 43"}
+		]
+	}`)
+	mux.HandleFunc("/orders/41", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			// assert rather than require, since this runs on the server goroutine
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "abc", r.PostForm.Get("productCode"))
+			w.Header().Set("content-type", siren.MediaType)
+			w.Write([]byte(`{"class": ["order"], "title": "Order 41 (updated)"}`))
+			return
+		}
+
+		w.Header().Set("content-type", siren.MediaType)
+		w.Write([]byte(`{
+			"class": ["order"],
+			"title": "Order 41",
+			"actions": [
+				{"name": "add-item", "method": "POST", "href": "/orders/41"},
+				{"name": "archive", "method": "POST", "href": "/orders/41/archive"}
+			]
+		}`))
+	})
+	mux.HandleFunc("/orders/41/archive", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestTraversal(t *testing.T) {
+	ts := traversalServer(t)
+	defer ts.Close()
+
+	c := New(WithBaseURL(ts.URL))
+	root := &siren.Entity{
+		Links: []siren.Link{{Rel: siren.Rels{"orders"}, Href: "/orders"}},
+	}
+
+	t.Run("follow and submit", func(t *testing.T) {
+		e, err := c.From(root).
+			WithContext(context.Background()).
+			Follow("orders").
+			Follow("item").
+			Submit("add-item", map[string]any{"productCode": "abc"}).
+			Entity()
+		require.NoError(t, err)
+		require.Equal(t, "Order 41 (updated)", e.Title)
+	})
+
+	t.Run("embedded representation", func(t *testing.T) {
+		e, err := c.From(root).Follow("orders").Follow("latest").Entity()
+		require.NoError(t, err)
+		require.Equal(t, "Order 43", e.Title)
+	})
+
+	t.Run("missing link", func(t *testing.T) {
+		e, err := c.From(root).
+			Follow("orders").
+			Follow("prev").
+			Submit("add-item", nil).
+			Entity()
+		require.Nil(t, e)
+		require.ErrorIs(t, err, ErrLinkNotFound)
+		require.EqualError(t, err, `step 2 (follow "prev"): link not found`)
+
+		var terr *TraversalError
+		require.ErrorAs(t, err, &terr)
+		require.Equal(t, 2, terr.Step)
+		require.Equal(t, "follow", terr.Op)
+		require.Equal(t, "prev", terr.Target)
+	})
+
+	t.Run("missing action", func(t *testing.T) {
+		tr := c.From(root).Follow("orders").Submit("cancel", nil)
+		require.ErrorIs(t, tr.Err(), ErrActionNotFound)
+		require.EqualError(t, tr.Err(), `step 2 (submit "cancel"): action not found`)
+	})
+
+	t.Run("no entity", func(t *testing.T) {
+		_, err := c.From(root).
+			Follow("orders").
+			Follow("item").
+			Submit("archive", nil).
+			Follow("self").
+			Entity()
+		require.ErrorIs(t, err, ErrNoEntity)
+		require.EqualError(t, err, `step 4 (follow "self"): step 3 returned no entity`)
+
+		_, err = c.From(nil).Follow("orders").Entity()
+		require.ErrorIs(t, err, ErrNoEntity)
+		require.EqualError(t, err, `step 1 (follow "orders"): traversal started with no entity`)
+	})
+
+	t.Run("request error", func(t *testing.T) {
+		_, err := c.From(root).Follow("orders").Follow("next").Follow("orders").Entity()
+
+		var herr *HTTPError
+		require.ErrorAs(t, err, &herr)
+		require.Equal(t, http.StatusNotFound, herr.StatusCode)
+		require.Contains(t, err.Error(), `step 2 (follow "next")`)
+	})
+}
@@ -0,0 +1,256 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	siren "github.com/dominicbarnes/go-siren"
+)
+
+var (
+	// ErrUnknownField is used when user data contains a field that the action
+	// does not declare.
+	ErrUnknownField = errors.New("unknown field")
+
+	// ErrInvalidFieldValue is used when a value does not match the type
+	// declared by an action field.
+	ErrInvalidFieldValue = errors.New("invalid field value")
+)
+
+// FieldError describes a problem with a single field of user data.
+type FieldError struct {
+	Name  string
+	Type  string
+	Value any
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %q: %v", e.Name, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError is used when user data fails validation, and lists every
+// field that had a problem.
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for x, f := range e.Fields {
+		msgs[x] = f.Error()
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors for each field, which allows using errors.Is and
+// errors.As to inspect them.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for x, f := range e.Fields {
+		errs[x] = f
+	}
+	return errs
+}
+
+// ValidateData checks the user data for an action against the fields it
+// declares. Each value must match the HTML5 input type of its field, and any
+// names that the action does not declare are rejected. When validation fails,
+// a *ValidationError is returned.
+//
+// Nil values are always allowed, and slices are validated element by element
+// to support multi-value fields.
+func ValidateData(action siren.Action, data map[string]any) error {
+	var errs []*FieldError
+
+	fields := make(map[string]bool, len(action.Fields))
+	for _, field := range action.Fields {
+		fields[field.Name] = true
+
+		value, ok := data[field.Name]
+		if !ok {
+			continue
+		}
+
+		if err := validateValue(field.Type, value); err != nil {
+			errs = append(errs, &FieldError{
+				Name:  field.Name,
+				Type:  field.Type,
+				Value: value,
+				Err:   err,
+			})
+		}
+	}
+
+	for _, key := range sortedKeys(data) {
+		if !fields[key] {
+			errs = append(errs, &FieldError{
+				Name:  key,
+				Value: data[key],
+				Err:   ErrUnknownField,
+			})
+		}
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Fields: errs}
+	}
+	return nil
+}
+
+var (
+	reColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+	reWeek  = regexp.MustCompile(`^\d{4}-W(0[1-9]|[1-4]\d|5[0-3])$`)
+)
+
+func validateValue(fieldType string, value any) error {
+	if value == nil {
+		return nil
+	}
+
+	if fieldType == "file" {
+		files, ok := asFiles("", value)
+		if !ok {
+			return invalid("expected a file")
+		}
+		for _, f := range files {
+			// a file without a reader has no contents to upload
+			if f.Reader == nil {
+				return invalid("missing file contents")
+			}
+		}
+		return nil
+	}
+
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice && !isScalar(value) {
+		for i := 0; i < rv.Len(); i++ {
+			if err := validateValue(fieldType, rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch fieldType {
+	case "number", "range":
+		if !isNumber(value) {
+			return invalid("expected a number")
+		}
+
+	case "checkbox":
+		switch value.(type) {
+		case bool, string:
+		default:
+			return invalid("expected a boolean or string")
+		}
+
+	case "email":
+		s, ok := value.(string)
+		if !ok {
+			return invalid("expected an email address")
+		}
+		if addr, err := mail.ParseAddress(s); err != nil || addr.Address != s {
+			return invalid("expected an email address")
+		}
+
+	case "url":
+		s, ok := value.(string)
+		if !ok {
+			return invalid("expected an absolute URL")
+		}
+		if u, err := url.Parse(s); err != nil || !u.IsAbs() {
+			return invalid("expected an absolute URL")
+		}
+
+	case "date":
+		return validateTime(value, "a date (YYYY-MM-DD)", "2006-01-02")
+
+	case "datetime-local":
+		return validateTime(value, "a local date and time (YYYY-MM-DDThh:mm)", "2006-01-02T15:04", "2006-01-02T15:04:05")
+
+	case "time":
+		return validateTime(value, "a time (hh:mm)", "15:04", "15:04:05")
+
+	case "month":
+		return validateTime(value, "a month (YYYY-MM)", "2006-01")
+
+	case "week":
+		if s, ok := value.(string); !ok || !reWeek.MatchString(s) {
+			return invalid("expected a week (YYYY-Www)")
+		}
+
+	case "color":
+		if s, ok := value.(string); !ok || !reColor.MatchString(s) {
+			return invalid("expected a color (#rrggbb)")
+		}
+
+	default:
+		if !isScalar(value) {
+			return invalid("expected a scalar value")
+		}
+	}
+
+	return nil
+}
+
+func validateTime(value any, expected string, layouts ...string) error {
+	switch v := value.(type) {
+	case time.Time:
+		return nil
+	case string:
+		for _, layout := range layouts {
+			if _, err := time.Parse(layout, v); err == nil {
+				return nil
+			}
+		}
+	}
+	return invalid("expected " + expected)
+}
+
+func isNumber(value any) bool {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	case json.Number:
+		_, err := v.Float64()
+		return err == nil
+	case string:
+		_, err := strconv.ParseFloat(v, 64)
+		return err == nil
+	}
+	return false
+}
+
+func isScalar(value any) bool {
+	if _, ok := formatValue(value); ok {
+		return true
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func invalid(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidFieldValue, reason)
+}
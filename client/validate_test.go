@@ -0,0 +1,114 @@
+package client_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	siren "github.com/dominicbarnes/go-siren"
+	. "github.com/dominicbarnes/go-siren/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateData(t *testing.T) {
+	type spec struct {
+		fieldType string
+		value     any
+		valid     bool
+	}
+
+	specs := map[string]spec{
+		"text string":             {"text", "hello", true},
+		"text number":             {"text", 42, true},
+		"text map":                {"text", map[string]any{}, false},
+		"untyped string":          {"", "hello", true},
+		"hidden":                  {"hidden", 42, true},
+		"number int":              {"number", 3, true},
+		"number float":            {"number", 3.5, true},
+		"number string":           {"number", "3", true},
+		"number invalid":          {"number", "three", false},
+		"range":                   {"range", 10, true},
+		"checkbox bool":           {"checkbox", true, true},
+		"checkbox string":         {"checkbox", "on", true},
+		"checkbox number":         {"checkbox", 1, false},
+		"multi-select":            {"select", []string{"a", "b"}, true},
+		"multi number invalid":    {"number", []any{1, "x"}, false},
+		"email":                   {"email", "jo@example.com", true},
+		"email display name":      {"email", "Jo <jo@example.com>", false},
+		"email invalid":           {"email", "jo", false},
+		"url":                     {"url", "https://example.com/a", true},
+		"url relative":            {"url", "/a", false},
+		"date":                    {"date", "2020-01-02", true},
+		"date time.Time":          {"date", time.Now(), true},
+		"date invalid":            {"date", "01/02/2020", false},
+		"datetime-local":          {"datetime-local", "2020-01-02T03:04", true},
+		"datetime-local seconds":  {"datetime-local", "2020-01-02T03:04:05", true},
+		"datetime-local invalid":  {"datetime-local", "2020-01-02", false},
+		"time":                    {"time", "13:45", true},
+		"time invalid":            {"time", "25:00", false},
+		"month":                   {"month", "2020-02", true},
+		"week":                    {"week", "2020-W09", true},
+		"week invalid":            {"week", "2020-W60", false},
+		"color":                   {"color", "#00ff7F", true},
+		"color invalid":           {"color", "red", false},
+		"file reader":             {"file", strings.NewReader(""), true},
+		"file struct":             {"file", File{Reader: strings.NewReader("")}, true},
+		"file string":             {"file", "report.pdf", false},
+		"file nil reader":         {"file", File{Name: "report.pdf"}, false},
+		"file pointer nil reader": {"file", &File{Name: "report.pdf"}, false},
+		"file slice nil reader":   {"file", []File{{Reader: strings.NewReader("")}, {}}, false},
+		"nil":                     {"number", nil, true},
+		"unrecognized field type": {"x-custom", "anything", true},
+	}
+
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			action := siren.Action{
+				Name:   "test",
+				Href:   "/",
+				Fields: []siren.ActionField{{Name: "field", Type: spec.fieldType}},
+			}
+			err := ValidateData(action, map[string]any{"field": spec.value})
+			if spec.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidFieldValue)
+			}
+		})
+	}
+}
+
+func TestValidateDataReport(t *testing.T) {
+	action := siren.Action{
+		Name: "add-item",
+		Href: "/orders/42/items",
+		Fields: []siren.ActionField{
+			{Name: "orderNumber", Type: "hidden"},
+			{Name: "productCode", Type: "text"},
+			{Name: "quantity", Type: "number"},
+			{Name: "email", Type: "email"},
+		},
+	}
+
+	err := ValidateData(action, map[string]any{
+		"productCode": "abc",
+		"quantity":    "lots",
+		"email":       "nope",
+		"color":       "red",
+	})
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Fields, 3)
+	require.Equal(t, "quantity", verr.Fields[0].Name)
+	require.Equal(t, "number", verr.Fields[0].Type)
+	require.Equal(t, "email", verr.Fields[1].Name)
+	require.Equal(t, "color", verr.Fields[2].Name)
+	require.ErrorIs(t, err, ErrUnknownField)
+	require.EqualError(t, err, `validation failed: field "quantity": invalid field value: expected a number; field "email": invalid field value: expected an email address; field "color": unknown field`)
+
+	var ferr *FieldError
+	require.ErrorAs(t, err, &ferr)
+	require.Equal(t, "quantity", ferr.Name)
+}
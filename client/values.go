@@ -0,0 +1,153 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	siren "github.com/dominicbarnes/go-siren"
+)
+
+// formValues flattens user data into url.Values using the following rules:
+//
+//   - nil values are skipped
+//   - slices and arrays become repeated keys
+//   - booleans become "true" or "false"
+//   - time.Time values are formatted as RFC 3339
+//   - nested maps become "parent[child]" keys when brackets is true, otherwise
+//     they are rejected with an error
+func formValues(data map[string]any, brackets bool) (url.Values, error) {
+	q := url.Values{}
+	for _, key := range sortedKeys(data) {
+		if err := addFormValue(q, key, data[key], brackets); err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func addFormValue(q url.Values, key string, value any, brackets bool) error {
+	if s, ok := formatValue(value); ok {
+		q.Add(key, s)
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := addFormValue(q, key, rv.Index(i).Interface(), brackets); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Map:
+		if !brackets {
+			return fmt.Errorf("field %q: nested values require bracket notation", key)
+		} else if rv.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("field %q: nested maps must have string keys", key)
+		}
+
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, k := range keys {
+			if err := addFormValue(q, key+"["+k.String()+"]", rv.MapIndex(k).Interface(), brackets); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	q.Add(key, fmt.Sprintf("%v", rv.Interface()))
+	return nil
+}
+
+// formatValue converts scalar values into their string representation, which
+// returns false for nil, slices, maps and other values that need special
+// handling.
+func formatValue(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), true
+	case json.Number:
+		return v.String(), true
+	case time.Time:
+		return v.Format(time.RFC3339), true
+	case *time.Time:
+		if v == nil {
+			return "", false
+		}
+		return v.Format(time.RFC3339), true
+	case fmt.Stringer:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return "", false
+		}
+		return v.String(), true
+	}
+
+	return "", false
+}
+
+// isFormEncoded reports whether the data for the action is sent as form values,
+// which is the case for GET actions and the form media types.
+func isFormEncoded(action siren.Action) bool {
+	if action.GetMethod() == http.MethodGet {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(action.GetType())
+	if err != nil {
+		return false
+	}
+
+	switch mediaType {
+	case "application/x-www-form-urlencoded", "multipart/form-data", "text/plain":
+		return true
+	}
+	return false
+}
+
+// isEmpty reports whether a field default should be omitted from the data.
+func isEmpty(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
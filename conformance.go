@@ -0,0 +1,230 @@
+package siren
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Severity indicates how serious a conformance finding is.
+type Severity int
+
+const (
+	// SeverityWarning is used for findings that are allowed by the spec, but
+	// are likely to cause problems for clients.
+	SeverityWarning Severity = iota + 1
+
+	// SeverityError is used for findings that violate the spec.
+	SeverityError
+)
+
+// String implements fmt.Stringer.
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Finding is a single problem found while checking an entity for conformance
+// with the siren spec. The path is a JSON pointer to where it was found.
+type Finding struct {
+	Path     string
+	Severity Severity
+	Message  string
+}
+
+// String implements fmt.Stringer.
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Severity, f.Path, f.Message)
+}
+
+// Findings is a list of conformance findings.
+type Findings []Finding
+
+// AtLeast returns the findings that are at least as severe as the given
+// severity, which allows ignoring warnings while adopting the checks.
+func (f Findings) AtLeast(severity Severity) Findings {
+	var filtered Findings
+	for _, finding := range f {
+		if finding.Severity >= severity {
+			filtered = append(filtered, finding)
+		}
+	}
+	return filtered
+}
+
+// HasErrors reports whether any of the findings are errors.
+func (f Findings) HasErrors() bool {
+	return len(f.AtLeast(SeverityError)) > 0
+}
+
+// ActionFieldTypes are the types that are valid for ActionField.Type, which are
+// the HTML5 input types along with the select and textarea form controls.
+var ActionFieldTypes = []string{
+	"hidden", "text", "search", "tel", "url", "email", "password",
+	"date", "month", "week", "time", "datetime-local",
+	"number", "range", "color", "checkbox", "radio", "file",
+	"select", "textarea",
+}
+
+// standardMethods are the HTTP methods defined by RFC 9110 and RFC 5789.
+var standardMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+	http.MethodPatch, http.MethodDelete, http.MethodConnect,
+	http.MethodOptions, http.MethodTrace,
+}
+
+// Check inspects the entity for conformance with the siren spec, going beyond
+// the required attributes covered by Validate, which are included as errors.
+// The following are also reported, recursing into sub-entities:
+//
+//   - duplicate action names within an entity (error)
+//   - duplicate field names within an action (error)
+//   - invalid HTTP methods for an action (error), or non-standard ones (warning)
+//   - link and action types that are not valid media types (error)
+//   - field types that are not listed in ActionFieldTypes (warning)
+func (e Entity) Check() Findings {
+	var findings Findings
+	for _, err := range e.validate("") {
+		findings = append(findings, Finding{
+			Path:     err.Path,
+			Severity: SeverityError,
+			Message:  err.Err.Error(),
+		})
+	}
+	return append(findings, e.check("")...)
+}
+
+func (e Entity) check(path string) Findings {
+	var findings Findings
+
+	for x, ee := range e.Entities {
+		findings = append(findings, ee.Entity.check(pointer(path, "entities", x))...)
+	}
+
+	for x, l := range e.Links {
+		if l.Type != "" && !isMediaType(l.Type) {
+			findings = append(findings, Finding{
+				Path:     pointer(path, "links", x) + "/type",
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("invalid media type %q", l.Type),
+			})
+		}
+	}
+
+	names := make(map[string]bool)
+	for x, a := range e.Actions {
+		p := pointer(path, "actions", x)
+		if a.Name != "" {
+			if names[a.Name] {
+				findings = append(findings, Finding{
+					Path:     p + "/name",
+					Severity: SeverityError,
+					Message:  fmt.Sprintf("duplicate action name %q", a.Name),
+				})
+			}
+			names[a.Name] = true
+		}
+		findings = append(findings, a.check(p)...)
+	}
+
+	return findings
+}
+
+func (a Action) check(path string) Findings {
+	var findings Findings
+
+	if a.Method != "" {
+		if finding, ok := checkMethod(a.Method); !ok {
+			finding.Path = path + "/method"
+			findings = append(findings, finding)
+		}
+	}
+
+	if a.Type != "" && !isMediaType(a.Type) {
+		findings = append(findings, Finding{
+			Path:     path + "/type",
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("invalid media type %q", a.Type),
+		})
+	}
+
+	names := make(map[string]bool)
+	for x, f := range a.Fields {
+		p := pointer(path, "fields", x)
+		if f.Name != "" {
+			if names[f.Name] {
+				findings = append(findings, Finding{
+					Path:     p + "/name",
+					Severity: SeverityError,
+					Message:  fmt.Sprintf("duplicate field name %q", f.Name),
+				})
+			}
+			names[f.Name] = true
+		}
+
+		if f.Type != "" && !contains(ActionFieldTypes, f.Type) {
+			findings = append(findings, Finding{
+				Path:     p + "/type",
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("unknown field type %q", f.Type),
+			})
+		}
+	}
+
+	return findings
+}
+
+func checkMethod(method string) (Finding, bool) {
+	if contains(standardMethods, method) {
+		return Finding{}, true
+	} else if !isToken(method) {
+		return Finding{
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("invalid HTTP method %q", method),
+		}, false
+	} else if contains(standardMethods, strings.ToUpper(method)) {
+		return Finding{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("HTTP method %q is case-sensitive, use %q", method, strings.ToUpper(method)),
+		}, false
+	}
+	return Finding{
+		Severity: SeverityWarning,
+		Message:  fmt.Sprintf("non-standard HTTP method %q", method),
+	}, false
+}
+
+func isMediaType(s string) bool {
+	mediaType, _, err := mime.ParseMediaType(s)
+	return err == nil && strings.Count(mediaType, "/") == 1 &&
+		!strings.HasPrefix(mediaType, "/") && !strings.HasSuffix(mediaType, "/")
+}
+
+// isToken checks the token production from RFC 9110, which HTTP methods must
+// follow.
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c > 0x7e || c <= ' ' || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, c) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package siren_test
+
+import (
+	"testing"
+
+	. "github.com/dominicbarnes/go-siren"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityCheck(t *testing.T) {
+	type spec struct {
+		input    Entity
+		expected Findings
+	}
+
+	specs := map[string]spec{
+		"conforming": {
+			input: Entity{
+				Links: []Link{{Rel: Rels{"self"}, Href: "/", Type: "application/vnd.siren+json"}},
+				Actions: []Action{
+					{
+						Name:   "add-item",
+						Href:   "/items",
+						Method: "POST",
+						Type:   "application/json",
+						Fields: []ActionField{{Name: "title", Type: "text"}},
+					},
+				},
+			},
+		},
+		"missing attributes": {
+			input: Entity{Links: []Link{{Href: "/"}}},
+			expected: Findings{
+				{Path: "/links/0/rel", Severity: SeverityError, Message: "zero value"},
+			},
+		},
+		"duplicate action names": {
+			input: Entity{
+				Actions: []Action{
+					{Name: "search", Href: "/search"},
+					{Name: "search", Href: "/find"},
+				},
+			},
+			expected: Findings{
+				{Path: "/actions/1/name", Severity: SeverityError, Message: `duplicate action name "search"`},
+			},
+		},
+		"duplicate field names": {
+			input: Entity{
+				Actions: []Action{
+					{
+						Name:   "search",
+						Href:   "/search",
+						Fields: []ActionField{{Name: "q"}, {Name: "page"}, {Name: "q"}},
+					},
+				},
+			},
+			expected: Findings{
+				{Path: "/actions/0/fields/2/name", Severity: SeverityError, Message: `duplicate field name "q"`},
+			},
+		},
+		"invalid method": {
+			input: Entity{
+				Actions: []Action{{Name: "a", Href: "/", Method: "GET /"}},
+			},
+			expected: Findings{
+				{Path: "/actions/0/method", Severity: SeverityError, Message: `invalid HTTP method "GET /"`},
+			},
+		},
+		"lowercase method": {
+			input: Entity{
+				Actions: []Action{{Name: "a", Href: "/", Method: "post"}},
+			},
+			expected: Findings{
+				{Path: "/actions/0/method", Severity: SeverityWarning, Message: `HTTP method "post" is case-sensitive, use "POST"`},
+			},
+		},
+		"non-standard method": {
+			input: Entity{
+				Actions: []Action{{Name: "a", Href: "/", Method: "PURGE"}},
+			},
+			expected: Findings{
+				{Path: "/actions/0/method", Severity: SeverityWarning, Message: `non-standard HTTP method "PURGE"`},
+			},
+		},
+		"unknown field type": {
+			input: Entity{
+				Actions: []Action{{Name: "a", Href: "/", Fields: []ActionField{{Name: "x", Type: "datetime"}}}},
+			},
+			expected: Findings{
+				{Path: "/actions/0/fields/0/type", Severity: SeverityWarning, Message: `unknown field type "datetime"`},
+			},
+		},
+		"form control field types": {
+			input: Entity{
+				Actions: []Action{{Name: "a", Href: "/", Fields: []ActionField{
+					{Name: "x", Type: "select"},
+					{Name: "y", Type: "textarea"},
+				}}},
+			},
+		},
+		"invalid types": {
+			input: Entity{
+				Links:   []Link{{Rel: Rels{"alternate"}, Href: "/a.pdf", Type: "pdf"}},
+				Actions: []Action{{Name: "a", Href: "/", Type: "json"}},
+			},
+			expected: Findings{
+				{Path: "/links/0/type", Severity: SeverityError, Message: `invalid media type "pdf"`},
+				{Path: "/actions/0/type", Severity: SeverityError, Message: `invalid media type "json"`},
+			},
+		},
+		"embedded link with properties": {
+			input: Entity{
+				Entities: []EmbeddedEntity{
+					{Rel: Rels{"item"}, Href: "/items/1", Entity: Entity{Properties: Properties{"id": 1}}},
+					{Rel: Rels{"item"}, Entity: Entity{Properties: Properties{"id": 2}}},
+				},
+			},
+			expected: Findings{
+				{Path: "/entities/0", Severity: SeverityError, Message: "siren: embedded link must not have properties, entities, links or actions"},
+			},
+		},
+		"nested": {
+			input: Entity{
+				Entities: []EmbeddedEntity{
+					{
+						Rel: Rels{"item"},
+						Entity: Entity{
+							Actions: []Action{
+								{Name: "a", Href: "/"},
+								{Name: "a", Href: "/", Method: "post"},
+							},
+						},
+					},
+				},
+			},
+			expected: Findings{
+				{Path: "/entities/0/actions/1/name", Severity: SeverityError, Message: `duplicate action name "a"`},
+				{Path: "/entities/0/actions/1/method", Severity: SeverityWarning, Message: `HTTP method "post" is case-sensitive, use "POST"`},
+			},
+		},
+	}
+
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, spec.expected, spec.input.Check())
+		})
+	}
+}
+
+func TestFindings(t *testing.T) {
+	findings := Findings{
+		{Path: "/actions/0/method", Severity: SeverityWarning, Message: `non-standard HTTP method "PURGE"`},
+		{Path: "/actions/1/name", Severity: SeverityError, Message: `duplicate action name "search"`},
+	}
+
+	require.True(t, findings.HasErrors())
+	require.False(t, findings[:1].HasErrors())
+	require.Equal(t, findings[1:], findings.AtLeast(SeverityError))
+	require.Equal(t, findings, findings.AtLeast(SeverityWarning))
+	require.Equal(t, `error: /actions/1/name: duplicate action name "search"`, findings[1].String())
+}
@@ -0,0 +1,190 @@
+package siren
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+var embeddedEntityType = reflect.TypeOf(EmbeddedEntity{})
+
+// ErrUnknownProperty is used when decoding with DecodeStrict and a property
+// has no matching struct field.
+var ErrUnknownProperty = errors.New("siren: unknown property")
+
+// DecodeOption is used to configure Entity.Decode and Properties.Decode.
+type DecodeOption func(*assigner)
+
+// DecodeStrict rejects properties that have no matching struct field with
+// ErrUnknownProperty, much like json.Decoder.DisallowUnknownFields.
+func DecodeStrict() DecodeOption {
+	return func(a *assigner) {
+		a.strict = true
+	}
+}
+
+// DecodeUseNumber stores numbers as json.Number rather than float64 when they
+// are decoded into an interface value, such as a field of type any or the
+// values of a map[string]any. This is like json.Decoder.UseNumber.
+func DecodeUseNumber() DecodeOption {
+	return func(a *assigner) {
+		a.useNumber = true
+	}
+}
+
+// DecodeTimeLayouts sets the layouts used to parse strings decoded into a
+// time.Time, which are tried in order. By default RFC 3339 is used, along with
+// the formats of the HTML5 datetime-local and date inputs.
+func DecodeTimeLayouts(layouts ...string) DecodeOption {
+	return func(a *assigner) {
+		a.timeLayouts = layouts
+	}
+}
+
+// Decode stores the properties in the value pointed to by v, which can be a
+// pointer to a struct or a *map[string]any. Struct fields are matched to
+// properties using the same struct tags as Marshal, and values are converted
+// to the field's type. (eg: a float64 can be decoded into an int field, and an
+// RFC 3339 string into a time.Time)
+func (p Properties) Decode(v any, opts ...DecodeOption) error {
+	return newAssigner(opts...).decodeInto(v, map[string]any(p))
+}
+
+// Decode stores the entity in the value pointed to by v, which is the inverse
+// of Marshal using the same struct tags.
+//
+// Properties are decoded as described by Properties.Decode. Title fields are
+// set to the title, and class fields are set to the classes (or the first class
+// for a string field). Entity fields are set to the sub-entities that have
+// every rel and class from their tag, where a slice receives every match and
+// other types receive the first match. Fields of type Entity or EmbeddedEntity
+// receive the entity as-is, and only EmbeddedEntity fields receive embedded
+// links since they have no representation to decode.
+func (e Entity) Decode(v any, opts ...DecodeOption) error {
+	dst, err := target(v)
+	if err != nil {
+		return err
+	}
+	return newAssigner(opts...).decodeEntity(dst, e, "")
+}
+
+func (a *assigner) decodeEntity(dst reflect.Value, e Entity, path string) error {
+	for dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		dst = dst.Elem()
+	}
+
+	if dst.Type() == entityType {
+		dst.Set(reflect.ValueOf(e))
+		return nil
+	}
+
+	props := copyData(map[string]any(e.Properties), a.useNumber).(map[string]any)
+
+	if dst.Kind() != reflect.Struct {
+		return a.assign(dst, props, path)
+	}
+
+	if err := a.assignStruct(dst, props, path); err != nil {
+		return err
+	}
+
+	fields, err := structFields(dst.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		switch f.kind {
+		case fieldTitle:
+			if err := a.assign(fieldByIndex(dst, f.index), e.Title, joinPath(path, f.name)); err != nil {
+				return err
+			}
+
+		case fieldClass:
+			fv := fieldByIndex(dst, f.index)
+			if fv.Kind() == reflect.String {
+				if len(e.Class) > 0 {
+					fv.SetString(e.Class[0])
+				}
+				continue
+			}
+			if err := a.assign(fv, []string(e.Class), joinPath(path, f.name)); err != nil {
+				return err
+			}
+
+		case fieldEntity:
+			if err := a.decodeEntities(fieldByIndex(dst, f.index), e.Entities, f, joinPath(path, f.name)); err != nil {
+				// nested sub-entities already include their path in the error,
+				// so this is only added once at the top level
+				if path == "" {
+					err = fmt.Errorf("siren: entity %q: %w", f.name, err)
+				}
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// decodeEntities decodes the sub-entities matching the field's rels and
+// classes into it.
+//
+// Embedded links have no representation to decode, so they are skipped unless
+// the field holds EmbeddedEntity values.
+func (a *assigner) decodeEntities(dst reflect.Value, entities []EmbeddedEntity, f structField, path string) error {
+	elem := dst.Type()
+	if elem.Kind() == reflect.Slice {
+		elem = elem.Elem()
+	}
+	links := embeddedEntityType.AssignableTo(elem)
+
+	var matches []EmbeddedEntity
+	for _, embed := range entities {
+		if (links || embed.IsRepresentation()) && matchesField(embed, f) {
+			matches = append(matches, embed)
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	if dst.Kind() != reflect.Slice {
+		return a.decodeEmbedded(dst, matches[0], path)
+	}
+
+	s := reflect.MakeSlice(dst.Type(), len(matches), len(matches))
+	for i, embed := range matches {
+		if err := a.decodeEmbedded(s.Index(i), embed, path+"["+strconv.Itoa(i)+"]"); err != nil {
+			return err
+		}
+	}
+	dst.Set(s)
+	return nil
+}
+
+func (a *assigner) decodeEmbedded(dst reflect.Value, embed EmbeddedEntity, path string) error {
+	if embeddedEntityType.AssignableTo(dst.Type()) {
+		dst.Set(reflect.ValueOf(embed))
+		return nil
+	}
+	return a.decodeEntity(dst, embed.Entity, path)
+}
+
+func matchesField(embed EmbeddedEntity, f structField) bool {
+	for _, rel := range f.rels {
+		if !embed.Rel.Contains(rel) {
+			return false
+		}
+	}
+	for _, class := range f.classes {
+		if !embed.HasClass(class) {
+			return false
+		}
+	}
+	return true
+}
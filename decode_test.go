@@ -0,0 +1,234 @@
+package siren_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/dominicbarnes/go-siren"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityDecode(t *testing.T) {
+	order := &Order{
+		Timestamps: Timestamps{Created: "2024-01-02"},
+		ID:         42,
+		Status:     "pending",
+		Label:      "Order #42",
+		Classes:    []string{"order"},
+		Items: []OrderItem{
+			{Kind: "product", Code: "ABC-123", Quantity: 2},
+			{Kind: "product", Code: "XYZ-789", Quantity: 1},
+		},
+		Customer: &Customer{Name: "Jane"},
+	}
+
+	// round-trip through JSON like a client would
+	e, err := Marshal(order)
+	require.NoError(t, err)
+	b, err := json.Marshal(e)
+	require.NoError(t, err)
+	var decoded Entity
+	require.NoError(t, json.Unmarshal(b, &decoded))
+
+	var actual Order
+	require.NoError(t, decoded.Decode(&actual))
+	require.Equal(t, order, &actual)
+}
+
+func TestEntityDecodeEntities(t *testing.T) {
+	e := Entity{
+		Entities: []EmbeddedEntity{
+			EmbeddedRepresentation{Entity: Entity{Class: Classes{"info"}, Properties: Properties{"name": "Jane"}}, Rel: Rels{"customer"}}.EmbeddedEntity(),
+			EmbeddedRepresentation{Entity: Entity{Class: Classes{"vip"}, Properties: Properties{"name": "John"}}, Rel: Rels{"customer"}}.EmbeddedEntity(),
+			EmbeddedLink{Rel: Rels{"customer"}, Href: "/customers/3", Class: Classes{"vip"}}.EmbeddedEntity(),
+		},
+	}
+
+	var actual struct {
+		First    Customer         `siren:"entity,rel=customer"`
+		VIPs     []*Customer      `siren:"entity,rel=customer,class=vip"`
+		Embedded []EmbeddedEntity `siren:"entity,rel=customer"`
+		Missing  *Customer        `siren:"entity,rel=author"`
+	}
+	require.NoError(t, e.Decode(&actual))
+	require.Equal(t, Customer{Name: "Jane"}, actual.First)
+	require.Equal(t, []*Customer{{Name: "John"}}, actual.VIPs, "embedded links are skipped")
+	require.Equal(t, e.Entities, actual.Embedded)
+	require.Nil(t, actual.Missing)
+}
+
+func TestEntityDecodeEmbeddedLinks(t *testing.T) {
+	e := Entity{
+		Entities: []EmbeddedEntity{
+			EmbeddedLink{Rel: Rels{"item"}, Href: "/items/1"}.EmbeddedEntity(),
+			EmbeddedLink{Rel: Rels{"item"}, Href: "/items/2"}.EmbeddedEntity(),
+		},
+	}
+
+	var actual struct {
+		Items []Customer       `siren:"entity,rel=item"`
+		First *Customer        `siren:"entity,rel=item"`
+		Links []EmbeddedEntity `siren:"entity,rel=item"`
+		Link  EmbeddedEntity   `siren:"entity,rel=item"`
+	}
+	require.NoError(t, e.Decode(&actual))
+	require.Nil(t, actual.Items)
+	require.Nil(t, actual.First)
+	require.Equal(t, e.Entities, actual.Links)
+	require.Equal(t, e.Entities[0], actual.Link)
+}
+
+func TestPropertiesDecode(t *testing.T) {
+	type dimensions struct {
+		Width  float64 `json:"width"`
+		Height float64 `json:"height"`
+	}
+
+	type product struct {
+		Code       string         `json:"code"`
+		Price      float64        `json:"price"`
+		Stock      int            `json:"stock"`
+		Tags       []string       `json:"tags"`
+		Dimensions dimensions     `json:"dimensions"`
+		Released   time.Time      `json:"released"`
+		Extra      map[string]any `json:"extra"`
+	}
+
+	var props Properties
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"code": "ABC-123",
+		"price": 9.99,
+		"stock": 12,
+		"tags": ["new", "sale"],
+		"dimensions": {"width": 10, "height": 20.5},
+		"released": "2024-01-02T15:04:05Z",
+		"extra": {"weight": 1.5}
+	}`), &props))
+
+	var actual product
+	require.NoError(t, props.Decode(&actual))
+	require.Equal(t, product{
+		Code:       "ABC-123",
+		Price:      9.99,
+		Stock:      12,
+		Tags:       []string{"new", "sale"},
+		Dimensions: dimensions{Width: 10, Height: 20.5},
+		Released:   time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+		Extra:      map[string]any{"weight": 1.5},
+	}, actual)
+
+	// the decoded maps are copies
+	actual.Extra["weight"] = 2
+	require.Equal(t, 1.5, props["extra"].(map[string]any)["weight"])
+}
+
+func TestPropertiesDecodeOptions(t *testing.T) {
+	props := Properties{
+		"id":      float64(9007199254740993),
+		"ratio":   0.5,
+		"when":    "02/01/2024",
+		"unknown": true,
+		"nested":  map[string]any{"count": float64(3)},
+	}
+
+	t.Run("use number", func(t *testing.T) {
+		var actual map[string]any
+		require.NoError(t, props.Decode(&actual, DecodeUseNumber()))
+		require.Equal(t, json.Number("0.5"), actual["ratio"])
+		require.Equal(t, map[string]any{"count": json.Number("3")}, actual["nested"])
+	})
+
+	t.Run("time layouts", func(t *testing.T) {
+		var actual struct {
+			When time.Time `json:"when"`
+		}
+		require.NoError(t, props.Decode(&actual, DecodeTimeLayouts("02/01/2006")))
+		require.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), actual.When)
+
+		err := props.Decode(&actual)
+		require.EqualError(t, err, `field "when": cannot parse "02/01/2024" as a time`)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		var actual struct {
+			Ratio  float64 `json:"ratio"`
+			Nested struct {
+				Count int `json:"count"`
+			} `json:"nested"`
+		}
+		require.NoError(t, props.Decode(&actual))
+		require.Equal(t, 0.5, actual.Ratio)
+		require.Equal(t, 3, actual.Nested.Count)
+
+		err := props.Decode(&actual, DecodeStrict())
+		require.ErrorIs(t, err, ErrUnknownProperty)
+		require.EqualError(t, err, `siren: unknown property: "id"`)
+	})
+}
+
+func TestDecodeErrors(t *testing.T) {
+	specs := map[string]struct {
+		entity   Entity
+		target   any
+		expected string
+	}{
+		"not a pointer": {
+			target:   Customer{},
+			expected: "siren: decode requires a non-nil pointer, got siren_test.Customer",
+		},
+		"wrong type": {
+			entity:   Entity{Properties: Properties{"name": 42.0}},
+			target:   new(Customer),
+			expected: `field "name": cannot decode float64 into string`,
+		},
+		"integer overflow": {
+			entity: Entity{Properties: Properties{"n": 300.0}},
+			target: new(struct {
+				N int8 `json:"n"`
+			}),
+			expected: `field "n": 300 overflows int8`,
+		},
+		"fraction into integer": {
+			entity: Entity{Properties: Properties{"n": 1.5}},
+			target: new(struct {
+				N int `json:"n"`
+			}),
+			expected: `field "n": 1.5 is not an integer`,
+		},
+		"sub-entity": {
+			entity: Entity{
+				Entities: []EmbeddedEntity{
+					EmbeddedRepresentation{Entity: Entity{Properties: Properties{"name": true}}, Rel: Rels{"customer"}}.EmbeddedEntity(),
+				},
+			},
+			target:   new(Order),
+			expected: `siren: entity "Customer": field "Customer.name": cannot decode bool into string`,
+		},
+		"nested sub-entity": {
+			entity: Entity{
+				Entities: []EmbeddedEntity{
+					EmbeddedRepresentation{
+						Entity: Entity{
+							Entities: []EmbeddedEntity{
+								EmbeddedRepresentation{Entity: Entity{Class: Classes{"item"}, Properties: Properties{"quantity": "two"}}, Rel: Rels{"item"}}.EmbeddedEntity(),
+							},
+						},
+						Rel: Rels{"order"},
+					}.EmbeddedEntity(),
+				},
+			},
+			target: new(struct {
+				Orders []Order `siren:"entity,rel=order"`
+			}),
+			expected: `siren: entity "Orders": field "Orders[0].Items[0].quantity": cannot parse "two" as an integer`,
+		},
+	}
+
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			require.EqualError(t, spec.entity.Decode(spec.target), spec.expected)
+		})
+	}
+}
@@ -1,18 +1,115 @@
 package siren
 
 import (
+	"errors"
+
 	validator "gopkg.in/validator.v2"
 )
 
+var (
+	// ErrMixedEmbeddedEntity is reported by Validate when an embedded link
+	// also carries attributes that only an embedded representation can have.
+	ErrMixedEmbeddedEntity = errors.New("siren: embedded link must not have properties, entities, links or actions")
+
+	// ErrTypedEmbeddedRepresentation is reported by Validate when an embedded
+	// representation has a type, which only an embedded link can have.
+	ErrTypedEmbeddedRepresentation = errors.New("siren: embedded representation must not have a type")
+)
+
 // EmbeddedEntity is a resource/link that is embedded within a parent entity.
 // An embedded resource may contain all the same attributes as any other Entity,
 // but also include a Rel indicating it's relationship to the parent. An
 // embedded link will only contain attributes that other links have. (eg: href,
 // rel, type, class, title)
+//
+// The two variants are distinguished by the href, which only an embedded link
+// has. Use IsLink and IsRepresentation to tell them apart, and EmbeddedLink or
+// EmbeddedRepresentation to construct either one correctly. Mixing the
+// attributes of both variants is reported by Entity.Validate.
 type EmbeddedEntity struct {
 	Entity
-	Rel  Rels `json:"rel" validate:"nonzero"`
-	Href Href `json:"href,omitempty"`
+	Rel  Rels   `json:"rel" validate:"nonzero"`
+	Href Href   `json:"href,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// IsLink reports whether this is an embedded link, which has an href.
+func (e EmbeddedEntity) IsLink() bool {
+	return e.Href != ""
+}
+
+// IsRepresentation reports whether this is an embedded representation, which
+// has no href.
+func (e EmbeddedEntity) IsRepresentation() bool {
+	return e.Href == ""
+}
+
+// AsLink returns this as an embedded link. When it is not an embedded link,
+// false is returned.
+func (e EmbeddedEntity) AsLink() (EmbeddedLink, bool) {
+	if !e.IsLink() {
+		return EmbeddedLink{}, false
+	}
+
+	return EmbeddedLink{
+		Rel:   e.Rel,
+		Href:  e.Href,
+		Type:  e.Type,
+		Title: e.Title,
+		Class: e.Class,
+	}, true
+}
+
+// AsRepresentation returns this as an embedded representation. When it is not
+// an embedded representation, false is returned.
+func (e EmbeddedEntity) AsRepresentation() (EmbeddedRepresentation, bool) {
+	if !e.IsRepresentation() {
+		return EmbeddedRepresentation{}, false
+	}
+
+	return EmbeddedRepresentation{
+		Entity: e.Entity,
+		Rel:    e.Rel,
+	}, true
+}
+
+// EmbeddedLink is the embedded link variant of a sub-entity.
+type EmbeddedLink struct {
+	Class Classes `json:"class,omitempty"`
+	Rel   Rels    `json:"rel"`
+	Href  Href    `json:"href"`
+	Type  string  `json:"type,omitempty"`
+	Title string  `json:"title,omitempty"`
+}
+
+// EmbeddedEntity converts this into an EmbeddedEntity for use in a parent
+// entity.
+func (l EmbeddedLink) EmbeddedEntity() EmbeddedEntity {
+	return EmbeddedEntity{
+		Entity: Entity{
+			Title: l.Title,
+			Class: l.Class,
+		},
+		Rel:  l.Rel,
+		Href: l.Href,
+		Type: l.Type,
+	}
+}
+
+// EmbeddedRepresentation is the embedded representation variant of a
+// sub-entity, which is a full entity along with its relationship to the parent.
+type EmbeddedRepresentation struct {
+	Entity
+	Rel Rels `json:"rel"`
+}
+
+// EmbeddedEntity converts this into an EmbeddedEntity for use in a parent
+// entity.
+func (r EmbeddedRepresentation) EmbeddedEntity() EmbeddedEntity {
+	return EmbeddedEntity{
+		Entity: r.Entity,
+		Rel:    r.Rel,
+	}
 }
 
 // Validate ensures that the embedded entity is well-formed.
@@ -27,5 +124,47 @@ func (e EmbeddedEntity) WithBaseHref(base Href) EmbeddedEntity {
 		Entity: e.Entity.WithBaseHref(base),
 		Rel:    e.Rel.WithBaseHref(base),
 		Href:   e.Href.WithBaseHref(base),
+		Type:   e.Type,
 	}
 }
+
+// WithoutBaseHref returns a copy of this embedded entity that removes the
+// supplied base href from the href and rels. This is the inverse of
+// WithBaseHref.
+func (e EmbeddedEntity) WithoutBaseHref(base Href) EmbeddedEntity {
+	return EmbeddedEntity{
+		Entity: e.Entity.WithoutBaseHref(base),
+		Rel:    e.Rel.WithoutBaseHref(base),
+		Href:   e.Href.Relative(base),
+		Type:   e.Type,
+	}
+}
+
+// MapHrefs returns a copy of this embedded entity with the href and rels
+// replaced by the result of the mapper, including those of the nested entity.
+// An empty href is left untouched.
+func (e EmbeddedEntity) MapHrefs(fn HrefMapper) (EmbeddedEntity, error) {
+	entity, err := e.Entity.MapHrefs(fn)
+	if err != nil {
+		return EmbeddedEntity{}, err
+	}
+
+	rel, err := e.Rel.MapHrefs(HrefKindEmbeddedRel, fn)
+	if err != nil {
+		return EmbeddedEntity{}, err
+	}
+
+	href := e.Href
+	if href != "" {
+		if href, err = fn(HrefKindEmbedded, href); err != nil {
+			return EmbeddedEntity{}, err
+		}
+	}
+
+	return EmbeddedEntity{
+		Entity: entity,
+		Rel:    rel,
+		Href:   href,
+		Type:   e.Type,
+	}, nil
+}
@@ -1,6 +1,7 @@
 package siren_test
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -74,3 +75,144 @@ func TestEmbeddedEntityWithBaseHref(t *testing.T) {
 		})
 	}
 }
+
+func TestEmbeddedEntityWithoutBaseHref(t *testing.T) {
+	e := EmbeddedEntity{
+		Rel:  Rels{"https://api.example.com/rels/custom", "item"},
+		Href: Href("https://api.example.com/items/1"),
+		Entity: Entity{
+			Links: []Link{{Rel: Rels{"self"}, Href: "https://api.example.com/items/1"}},
+		},
+	}
+	expected := EmbeddedEntity{
+		Rel:  Rels{"/rels/custom", "item"},
+		Href: Href("/items/1"),
+		Entity: Entity{
+			Links: []Link{{Rel: Rels{"self"}, Href: "/items/1"}},
+		},
+	}
+	require.EqualValues(t, expected, e.WithoutBaseHref("https://api.example.com"))
+}
+
+func TestEmbeddedEntityVariants(t *testing.T) {
+	link := EmbeddedLink{
+		Class: Classes{"items", "collection"},
+		Rel:   Rels{"http://x.io/rels/order-items"},
+		Href:  "/orders/42/items",
+		Type:  MediaType,
+		Title: "Items",
+	}.EmbeddedEntity()
+
+	representation := EmbeddedRepresentation{
+		Rel: Rels{"http://x.io/rels/customer"},
+		Entity: Entity{
+			Class:      Classes{"info", "customer"},
+			Properties: Properties{"customerId": "pj123"},
+			Links:      []Link{{Rel: Rels{"self"}, Href: "/customers/pj123"}},
+		},
+	}.EmbeddedEntity()
+
+	t.Run("IsLink()", func(t *testing.T) {
+		require.True(t, link.IsLink())
+		require.False(t, representation.IsLink())
+	})
+
+	t.Run("IsRepresentation()", func(t *testing.T) {
+		require.False(t, link.IsRepresentation())
+		require.True(t, representation.IsRepresentation())
+	})
+
+	t.Run("AsLink()", func(t *testing.T) {
+		l, ok := link.AsLink()
+		require.True(t, ok)
+		require.Equal(t, EmbeddedLink{
+			Class: Classes{"items", "collection"},
+			Rel:   Rels{"http://x.io/rels/order-items"},
+			Href:  "/orders/42/items",
+			Type:  MediaType,
+			Title: "Items",
+		}, l)
+
+		_, ok = representation.AsLink()
+		require.False(t, ok)
+	})
+
+	t.Run("AsRepresentation()", func(t *testing.T) {
+		r, ok := representation.AsRepresentation()
+		require.True(t, ok)
+		require.Equal(t, representation.Entity, r.Entity)
+		require.Equal(t, representation.Rel, r.Rel)
+
+		_, ok = link.AsRepresentation()
+		require.False(t, ok)
+	})
+
+	t.Run("MarshalJSON()", func(t *testing.T) {
+		b, err := json.Marshal(link)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"class": ["items", "collection"],
+			"rel": ["http://x.io/rels/order-items"],
+			"href": "/orders/42/items",
+			"type": "application/vnd.siren+json",
+			"title": "Items"
+		}`, string(b))
+
+		b, err = json.Marshal(representation)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"class": ["info", "customer"],
+			"rel": ["http://x.io/rels/customer"],
+			"properties": {"customerId": "pj123"},
+			"links": [{"rel": ["self"], "href": "/customers/pj123"}]
+		}`, string(b))
+	})
+
+	t.Run("MarshalJSON() mixed", func(t *testing.T) {
+		// mixed entities are still written as-is, and reported by Validate
+		mixed := link
+		mixed.Properties = Properties{"id": 1}
+		b, err := json.Marshal(Entity{Entities: []EmbeddedEntity{mixed}})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"entities": [{
+			"class": ["items", "collection"],
+			"rel": ["http://x.io/rels/order-items"],
+			"href": "/orders/42/items",
+			"type": "application/vnd.siren+json",
+			"title": "Items",
+			"properties": {"id": 1}
+		}]}`, string(b))
+
+		typed := representation
+		typed.Type = MediaType
+		_, err = json.Marshal(Entity{Entities: []EmbeddedEntity{typed}})
+		require.NoError(t, err)
+	})
+
+	t.Run("Validate() mixed", func(t *testing.T) {
+		mixed := link
+		mixed.Properties = Properties{"id": 1}
+		err := Entity{Entities: []EmbeddedEntity{mixed}}.Validate()
+		require.ErrorIs(t, err, ErrMixedEmbeddedEntity)
+		require.EqualError(t, err, "/entities/0: siren: embedded link must not have properties, entities, links or actions")
+
+		typed := representation
+		typed.Type = MediaType
+		err = Entity{Entities: []EmbeddedEntity{typed}}.Validate()
+		require.ErrorIs(t, err, ErrTypedEmbeddedRepresentation)
+		require.EqualError(t, err, "/entities/0/type: siren: embedded representation must not have a type")
+	})
+
+	t.Run("UnmarshalJSON()", func(t *testing.T) {
+		var actual EmbeddedEntity
+		err := json.Unmarshal([]byte(`{
+			"class": ["items", "collection"],
+			"rel": ["http://x.io/rels/order-items"],
+			"href": "/orders/42/items",
+			"type": "application/vnd.siren+json",
+			"title": "Items"
+		}`), &actual)
+		require.NoError(t, err)
+		require.Equal(t, link, actual)
+	})
+}
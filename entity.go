@@ -1,9 +1,5 @@
 package siren
 
-import (
-	"gopkg.in/validator.v2"
-)
-
 // Entity is a top-level resource in a siren API.
 type Entity struct {
 	Entities   []EmbeddedEntity `json:"entities,omitempty"`
@@ -14,28 +10,17 @@ type Entity struct {
 	Class      Classes          `json:"class,omitempty"`
 }
 
-// Validate ensures that the entity, embedded entities, links and actions are
-// all well-formed.
+// Validate ensures that the entity, embedded entities, links, actions and
+// action fields are all well-formed, recursing into nested sub-entities.
+//
+// Every problem is reported rather than just the first one, so the error is
+// ValidationErrors, where each problem includes a JSON pointer to where it was
+// found. (eg: "/entities/2/actions/0/fields/1/name")
 func (e Entity) Validate() error {
-	for _, ee := range e.Entities {
-		if err := validator.Validate(ee); err != nil {
-			return err
-		}
-	}
-
-	for _, l := range e.Links {
-		if err := validator.Validate(l); err != nil {
-			return err
-		}
+	if errs := e.validate(""); len(errs) > 0 {
+		return errs
 	}
-
-	for _, a := range e.Actions {
-		if err := validator.Validate(a); err != nil {
-			return err
-		}
-	}
-
-	return validator.Validate(e)
+	return nil
 }
 
 // WithBaseHref applies the given base href to the sub-entities, links and
@@ -65,3 +50,137 @@ func (e Entity) WithBaseHref(base Href) Entity {
 		Class:      e.Class,
 	}
 }
+
+// WithoutBaseHref removes the given base href from the sub-entities, links and
+// actions. This is the inverse of WithBaseHref.
+func (e Entity) WithoutBaseHref(base Href) Entity {
+	var entities []EmbeddedEntity
+	for _, embed := range e.Entities {
+		entities = append(entities, embed.WithoutBaseHref(base))
+	}
+
+	var links []Link
+	for _, link := range e.Links {
+		links = append(links, link.WithoutBaseHref(base))
+	}
+
+	var actions []Action
+	for _, action := range e.Actions {
+		actions = append(actions, action.WithoutBaseHref(base))
+	}
+
+	return Entity{
+		Entities:   entities,
+		Links:      links,
+		Actions:    actions,
+		Properties: e.Properties,
+		Title:      e.Title,
+		Class:      e.Class,
+	}
+}
+
+// MapHrefs returns a copy of this entity with every href and rel replaced by
+// the result of the mapper, including those of nested sub-entities. This can be
+// used for any rewriting that WithBaseHref does not cover, such as changing
+// hosts, signing URLs or adding prefixes.
+//
+// Embedded representations have no href of their own, so only their rels and
+// nested hrefs are visited. The first error returned by the mapper stops the
+// walk and is returned.
+func (e Entity) MapHrefs(fn HrefMapper) (Entity, error) {
+	var entities []EmbeddedEntity
+	for _, embed := range e.Entities {
+		mapped, err := embed.MapHrefs(fn)
+		if err != nil {
+			return Entity{}, err
+		}
+		entities = append(entities, mapped)
+	}
+
+	var links []Link
+	for _, link := range e.Links {
+		mapped, err := link.MapHrefs(fn)
+		if err != nil {
+			return Entity{}, err
+		}
+		links = append(links, mapped)
+	}
+
+	var actions []Action
+	for _, action := range e.Actions {
+		mapped, err := action.MapHrefs(fn)
+		if err != nil {
+			return Entity{}, err
+		}
+		actions = append(actions, mapped)
+	}
+
+	return Entity{
+		Entities:   entities,
+		Links:      links,
+		Actions:    actions,
+		Properties: e.Properties,
+		Title:      e.Title,
+		Class:      e.Class,
+	}, nil
+}
+
+// HasClass reports whether the entity has the given class name.
+func (e Entity) HasClass(class string) bool {
+	return e.Class.Contains(class)
+}
+
+// LinkByRel returns the first link with the given rel. When there is no such
+// link, false is returned.
+func (e Entity) LinkByRel(rel Href) (Link, bool) {
+	for _, link := range e.Links {
+		if link.Rel.Contains(rel) {
+			return link, true
+		}
+	}
+	return Link{}, false
+}
+
+// LinksByRel returns all the links with the given rel.
+func (e Entity) LinksByRel(rel Href) []Link {
+	var links []Link
+	for _, link := range e.Links {
+		if link.Rel.Contains(rel) {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// ActionByName returns the action with the given name. When there is no such
+// action, false is returned.
+func (e Entity) ActionByName(name string) (Action, bool) {
+	for _, action := range e.Actions {
+		if action.Name == name {
+			return action, true
+		}
+	}
+	return Action{}, false
+}
+
+// EntitiesByRel returns all the sub-entities with the given rel.
+func (e Entity) EntitiesByRel(rel Href) []EmbeddedEntity {
+	var entities []EmbeddedEntity
+	for _, embed := range e.Entities {
+		if embed.Rel.Contains(rel) {
+			entities = append(entities, embed)
+		}
+	}
+	return entities
+}
+
+// EntitiesByClass returns all the sub-entities with the given class name.
+func (e Entity) EntitiesByClass(class string) []EmbeddedEntity {
+	var entities []EmbeddedEntity
+	for _, embed := range e.Entities {
+		if embed.HasClass(class) {
+			entities = append(entities, embed)
+		}
+	}
+	return entities
+}
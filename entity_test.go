@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	. "github.com/dominicbarnes/go-siren"
@@ -29,7 +30,7 @@ func TestEntityValidate(t *testing.T) {
 					{},
 				},
 			},
-			Expected: errors.New("Rel: zero value"),
+			Expected: errors.New("/entities/0/rel: zero value"),
 		},
 		"link valid": {
 			Input: Entity{
@@ -44,7 +45,7 @@ func TestEntityValidate(t *testing.T) {
 					{Href: "/"},
 				},
 			},
-			Expected: errors.New("Rel: zero value"),
+			Expected: errors.New("/links/0/rel: zero value"),
 		},
 		"link missing href": {
 			Input: Entity{
@@ -52,7 +53,7 @@ func TestEntityValidate(t *testing.T) {
 					{Rel: Rels{"self"}},
 				},
 			},
-			Expected: errors.New("Href: zero value"),
+			Expected: errors.New("/links/0/href: zero value"),
 		},
 		"action valid": {
 			Input: Entity{
@@ -67,7 +68,7 @@ func TestEntityValidate(t *testing.T) {
 					{Href: "/search"},
 				},
 			},
-			Expected: errors.New("Name: zero value"),
+			Expected: errors.New("/actions/0/name: zero value"),
 		},
 		"action missing href": {
 			Input: Entity{
@@ -75,10 +76,52 @@ func TestEntityValidate(t *testing.T) {
 					{Name: "search"},
 				},
 			},
-			Expected: errors.New("Href: zero value"),
+			Expected: errors.New("/actions/0/href: zero value"),
 		},
 	})
 
+	t.Run("report", func(t *testing.T) {
+		e := Entity{
+			Entities: []EmbeddedEntity{
+				{Rel: Rels{"item"}, Href: "/items/1"},
+				{
+					Rel: Rels{"item"},
+					Entity: Entity{
+						Links: []Link{{Rel: Rels{"self"}}},
+						Actions: []Action{
+							{
+								Name: "update",
+								Href: "/items/2",
+								Fields: []ActionField{
+									{Name: "title"},
+									{Type: "text"},
+								},
+							},
+						},
+					},
+				},
+			},
+			Links: []Link{{}},
+		}
+
+		err := e.Validate()
+		require.EqualError(t, err, strings.Join([]string{
+			"/entities/1/links/0/href: zero value",
+			"/entities/1/actions/0/fields/1/name: zero value",
+			"/links/0/rel: zero value",
+			"/links/0/href: zero value",
+		}, "; "))
+		require.ErrorIs(t, err, ErrZeroValue)
+
+		var errs ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 4)
+
+		var verr *ValidationError
+		require.ErrorAs(t, err, &verr)
+		require.Equal(t, "/entities/1/links/0/href", verr.Path)
+	})
+
 	t.Run("WithBaseHref()", func(t *testing.T) {
 		e := Entity{
 			Entities: []EmbeddedEntity{
@@ -105,6 +148,195 @@ func TestEntityValidate(t *testing.T) {
 		actual := e.WithBaseHref("https://api.example.com")
 		require.EqualValues(t, expected, actual)
 	})
+
+	t.Run("WithoutBaseHref()", func(t *testing.T) {
+		internal := Entity{
+			Entities: []EmbeddedEntity{
+				{Href: "http://orders.internal:8080/posts/1", Rel: Rels{"item"}},
+			},
+			Links: []Link{
+				{Href: "http://orders.internal:8080/", Rel: Rels{"self"}},
+			},
+			Actions: []Action{
+				{Name: "search", Href: "http://orders.internal:8080/search"},
+			},
+		}
+		public := Entity{
+			Entities: []EmbeddedEntity{
+				{Href: "https://api.example.com/posts/1", Rel: Rels{"item"}},
+			},
+			Links: []Link{
+				{Href: "https://api.example.com/", Rel: Rels{"self"}},
+			},
+			Actions: []Action{
+				{Name: "search", Href: "https://api.example.com/search"},
+			},
+		}
+		actual := internal.
+			WithoutBaseHref("http://orders.internal:8080").
+			WithBaseHref("https://api.example.com")
+		require.EqualValues(t, public, actual)
+	})
+}
+
+func TestEntityNavigation(t *testing.T) {
+	e := Entity{
+		Class: Classes{"order"},
+		Entities: []EmbeddedEntity{
+			{Rel: Rels{"item"}, Href: "/items/1", Entity: Entity{Class: Classes{"item"}}},
+			{Rel: Rels{"item"}, Href: "/items/2", Entity: Entity{Class: Classes{"item", "sale"}}},
+			{Rel: Rels{"http://x.io/rels/customer"}, Href: "/customers/1"},
+		},
+		Links: []Link{
+			{Rel: Rels{"self"}, Href: "/orders/42"},
+			{Rel: Rels{"next", "http://x.io/rels/next-order"}, Href: "/orders/43"},
+			{Rel: Rels{"alternate"}, Href: "/orders/42.pdf", Type: "application/pdf"},
+			{Rel: Rels{"alternate"}, Href: "/orders/42.csv", Type: "text/csv"},
+		},
+		Actions: []Action{
+			{Name: "add-item", Href: "/orders/42/items"},
+			{Name: "cancel", Href: "/orders/42"},
+		},
+	}
+
+	t.Run("HasClass()", func(t *testing.T) {
+		require.True(t, e.HasClass("order"))
+		require.False(t, e.HasClass("item"))
+	})
+
+	t.Run("LinkByRel()", func(t *testing.T) {
+		link, ok := e.LinkByRel("next")
+		require.True(t, ok)
+		require.Equal(t, Href("/orders/43"), link.Href)
+
+		link, ok = e.LinkByRel("NEXT")
+		require.True(t, ok)
+		require.Equal(t, Href("/orders/43"), link.Href)
+
+		link, ok = e.LinkByRel("http://x.io/rels/next-order")
+		require.True(t, ok)
+		require.Equal(t, Href("/orders/43"), link.Href)
+
+		_, ok = e.LinkByRel("HTTP://X.IO/RELS/NEXT-ORDER")
+		require.False(t, ok)
+
+		_, ok = e.LinkByRel("prev")
+		require.False(t, ok)
+	})
+
+	t.Run("LinksByRel()", func(t *testing.T) {
+		links := e.LinksByRel("alternate")
+		require.Len(t, links, 2)
+		require.Equal(t, "application/pdf", links[0].Type)
+		require.Equal(t, "text/csv", links[1].Type)
+
+		require.Empty(t, e.LinksByRel("prev"))
+	})
+
+	t.Run("ActionByName()", func(t *testing.T) {
+		action, ok := e.ActionByName("cancel")
+		require.True(t, ok)
+		require.Equal(t, "cancel", action.Name)
+
+		_, ok = e.ActionByName("delete")
+		require.False(t, ok)
+	})
+
+	t.Run("EntitiesByRel()", func(t *testing.T) {
+		require.Len(t, e.EntitiesByRel("item"), 2)
+		require.Len(t, e.EntitiesByRel("http://x.io/rels/customer"), 1)
+		require.Empty(t, e.EntitiesByRel("author"))
+	})
+
+	t.Run("EntitiesByClass()", func(t *testing.T) {
+		require.Len(t, e.EntitiesByClass("item"), 2)
+
+		sale := e.EntitiesByClass("sale")
+		require.Len(t, sale, 1)
+		require.Equal(t, Href("/items/2"), sale[0].Href)
+
+		require.Empty(t, e.EntitiesByClass("customer"))
+	})
+}
+
+func TestEntityMapHrefs(t *testing.T) {
+	e := Entity{
+		Entities: []EmbeddedEntity{
+			{Href: "http://internal/posts/1", Rel: Rels{"item"}},
+			{
+				Rel: Rels{"http://internal/rels/author"},
+				Entity: Entity{
+					Links: []Link{{Href: "http://internal/users/1", Rel: Rels{"self"}}},
+				},
+			},
+		},
+		Links: []Link{
+			{Href: "http://internal/", Rel: Rels{"self", "http://internal/rels/home"}},
+		},
+		Actions: []Action{
+			{Name: "search", Href: "http://internal/search"},
+		},
+		Properties: Properties{"id": 1},
+	}
+
+	t.Run("rewrite", func(t *testing.T) {
+		visited := make(map[HrefKind][]Href)
+		actual, err := e.MapHrefs(func(kind HrefKind, h Href) (Href, error) {
+			visited[kind] = append(visited[kind], h)
+			return Href(strings.Replace(string(h), "http://internal", "https://api.example.com", 1)), nil
+		})
+		require.NoError(t, err)
+
+		expected := Entity{
+			Entities: []EmbeddedEntity{
+				{Href: "https://api.example.com/posts/1", Rel: Rels{"item"}},
+				{
+					Rel: Rels{"https://api.example.com/rels/author"},
+					Entity: Entity{
+						Links: []Link{{Href: "https://api.example.com/users/1", Rel: Rels{"self"}}},
+					},
+				},
+			},
+			Links: []Link{
+				{Href: "https://api.example.com/", Rel: Rels{"self", "https://api.example.com/rels/home"}},
+			},
+			Actions: []Action{
+				{Name: "search", Href: "https://api.example.com/search"},
+			},
+			Properties: Properties{"id": 1},
+		}
+		require.EqualValues(t, expected, actual)
+
+		require.Equal(t, map[HrefKind][]Href{
+			HrefKindEmbedded:    {"http://internal/posts/1"},
+			HrefKindEmbeddedRel: {"item", "http://internal/rels/author"},
+			HrefKindLink:        {"http://internal/users/1", "http://internal/"},
+			HrefKindLinkRel:     {"self", "self", "http://internal/rels/home"},
+			HrefKindAction:      {"http://internal/search"},
+		}, visited)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := e.MapHrefs(func(kind HrefKind, h Href) (Href, error) {
+			if kind == HrefKindAction {
+				return "", fmt.Errorf("cannot sign %s href %s", kind, h)
+			}
+			return h, nil
+		})
+		require.EqualError(t, err, "cannot sign action href http://internal/search")
+	})
+
+	t.Run("nil rels", func(t *testing.T) {
+		identity := func(kind HrefKind, h Href) (Href, error) { return h, nil }
+
+		rels, err := Rels(nil).MapHrefs(HrefKindLinkRel, identity)
+		require.NoError(t, err)
+		require.Nil(t, rels)
+
+		actual, err := Entity{Links: []Link{{Href: "/"}}}.MapHrefs(identity)
+		require.NoError(t, err)
+		require.Equal(t, Entity{Links: []Link{{Href: "/"}}}, actual)
+	})
 }
 
 func ExampleEntity() {
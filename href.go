@@ -1,6 +1,15 @@
 package siren
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrRelativeBaseHref is used when resolving an href against a base href that
+// is not an absolute URL.
+var ErrRelativeBaseHref = errors.New("siren: base href must be an absolute URL")
 
 // Href is a wrapper for a string that is a resource href that can automatically
 // be prefixed with a base href.
@@ -8,6 +17,10 @@ type Href string
 
 // WithBaseHref applies the given base href to this href. This prefix is only
 // applied when the href begins with a "/".
+//
+// This is a plain string concatenation, so any path in the base is kept as-is
+// and other relative references are not resolved. Prefer Resolve for resolving
+// references the way a browser would.
 func (h Href) WithBaseHref(base Href) Href {
 	if strings.HasPrefix(string(h), "/") {
 		return base + h
@@ -15,3 +28,113 @@ func (h Href) WithBaseHref(base Href) Href {
 
 	return h
 }
+
+// Resolve resolves this href as a URI reference against the given base href,
+// following RFC 3986 section 5. This supports absolute paths, relative paths
+// (including "./" and "../" segments), query-only and fragment-only references,
+// while absolute hrefs are returned unchanged.
+//
+// The base must be an absolute URL, otherwise ErrRelativeBaseHref is returned.
+// An error is also returned when either href is not a valid URL.
+func (h Href) Resolve(base Href) (Href, error) {
+	b, err := url.Parse(string(base))
+	if err != nil {
+		return "", fmt.Errorf("siren: invalid base href: %w", err)
+	} else if !b.IsAbs() {
+		return "", fmt.Errorf("%w: %q", ErrRelativeBaseHref, base)
+	}
+
+	ref, err := url.Parse(string(h))
+	if err != nil {
+		return "", fmt.Errorf("siren: invalid href: %w", err)
+	}
+
+	return Href(b.ResolveReference(ref).String()), nil
+}
+
+// Relative is the inverse of WithBaseHref, which removes the given base from
+// this href when it is an absolute URL under the same scheme, host and path.
+// The result always begins with a "/", so applying WithBaseHref with the same
+// base restores the original href. Any other href is returned unchanged.
+//
+// This allows rewriting hrefs between hosts, for example to expose the
+// entities of an internal service on a public host:
+//
+//	h.Relative("http://orders.internal").WithBaseHref("https://api.example.com")
+func (h Href) Relative(base Href) Href {
+	u, err := url.Parse(string(h))
+	if err != nil || !u.IsAbs() {
+		return h
+	}
+
+	b, err := url.Parse(string(base))
+	if err != nil || !b.IsAbs() {
+		return h
+	}
+
+	if !strings.EqualFold(u.Scheme, b.Scheme) || !strings.EqualFold(u.Host, b.Host) {
+		return h
+	} else if u.User.String() != b.User.String() {
+		return h
+	}
+
+	path := u.EscapedPath()
+	if prefix := strings.TrimSuffix(b.EscapedPath(), "/"); prefix != "" {
+		if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			return h
+		}
+		path = strings.TrimPrefix(path, prefix)
+	}
+	if path == "" {
+		path = "/"
+	}
+
+	if u.ForceQuery || u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	if u.Fragment != "" {
+		path += "#" + u.EscapedFragment()
+	}
+	return Href(path)
+}
+
+// HrefKind describes where an href appears within an entity.
+type HrefKind int
+
+const (
+	// HrefKindLink is the href of a link.
+	HrefKindLink HrefKind = iota + 1
+
+	// HrefKindAction is the href of an action.
+	HrefKindAction
+
+	// HrefKindEmbedded is the href of an embedded link.
+	HrefKindEmbedded
+
+	// HrefKindLinkRel is one of the rels of a link.
+	HrefKindLinkRel
+
+	// HrefKindEmbeddedRel is one of the rels of a sub-entity.
+	HrefKindEmbeddedRel
+)
+
+// String implements fmt.Stringer.
+func (k HrefKind) String() string {
+	switch k {
+	case HrefKindLink:
+		return "link"
+	case HrefKindAction:
+		return "action"
+	case HrefKindEmbedded:
+		return "embedded"
+	case HrefKindLinkRel:
+		return "link rel"
+	case HrefKindEmbeddedRel:
+		return "embedded rel"
+	}
+	return fmt.Sprintf("HrefKind(%d)", int(k))
+}
+
+// HrefMapper is a function used to rewrite the hrefs within an entity. It is
+// told which kind of href it is looking at, and returns the replacement.
+type HrefMapper func(kind HrefKind, h Href) (Href, error)
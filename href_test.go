@@ -0,0 +1,135 @@
+package siren_test
+
+import (
+	"testing"
+
+	. "github.com/dominicbarnes/go-siren"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHrefWithBaseHref(t *testing.T) {
+	require.Equal(t, Href("https://api.example.com/orders"), Href("/orders").WithBaseHref("https://api.example.com"))
+	require.Equal(t, Href("orders"), Href("orders").WithBaseHref("https://api.example.com"))
+}
+
+func TestHrefResolve(t *testing.T) {
+	// https://www.rfc-editor.org/rfc/rfc3986#section-5.4
+	const base = "http://a/b/c/d;p?q"
+
+	specs := map[string]string{
+		// normal examples
+		"g:h":     "g:h",
+		"g":       "http://a/b/c/g",
+		"./g":     "http://a/b/c/g",
+		"g/":      "http://a/b/c/g/",
+		"/g":      "http://a/g",
+		"//g":     "http://g",
+		"?y":      "http://a/b/c/d;p?y",
+		"g?y":     "http://a/b/c/g?y",
+		"#s":      "http://a/b/c/d;p?q#s",
+		"g#s":     "http://a/b/c/g#s",
+		"g?y#s":   "http://a/b/c/g?y#s",
+		";x":      "http://a/b/c/;x",
+		"g;x":     "http://a/b/c/g;x",
+		"g;x?y#s": "http://a/b/c/g;x?y#s",
+		"":        "http://a/b/c/d;p?q",
+		".":       "http://a/b/c/",
+		"./":      "http://a/b/c/",
+		"..":      "http://a/b/",
+		"../":     "http://a/b/",
+		"../g":    "http://a/b/g",
+		"../..":   "http://a/",
+		"../../":  "http://a/",
+		"../../g": "http://a/g",
+
+		// abnormal examples
+		"../../../g":    "http://a/g",
+		"../../../../g": "http://a/g",
+		"/./g":          "http://a/g",
+		"/../g":         "http://a/g",
+		"g.":            "http://a/b/c/g.",
+		".g":            "http://a/b/c/.g",
+		"g..":           "http://a/b/c/g..",
+		"..g":           "http://a/b/c/..g",
+		"./../g":        "http://a/b/g",
+		"./g/.":         "http://a/b/c/g/",
+		"g/./h":         "http://a/b/c/g/h",
+		"g/../h":        "http://a/b/c/h",
+		"g;x=1/./y":     "http://a/b/c/g;x=1/y",
+		"g;x=1/../y":    "http://a/b/c/y",
+		"g?y/./x":       "http://a/b/c/g?y/./x",
+		"g?y/../x":      "http://a/b/c/g?y/../x",
+		"g#s/./x":       "http://a/b/c/g#s/./x",
+		"g#s/../x":      "http://a/b/c/g#s/../x",
+	}
+
+	for ref, expected := range specs {
+		t.Run(ref, func(t *testing.T) {
+			actual, err := Href(ref).Resolve(base)
+			require.NoError(t, err)
+			require.Equal(t, Href(expected), actual)
+		})
+	}
+
+	t.Run("base with path", func(t *testing.T) {
+		actual, err := Href("orders").Resolve("https://api.x.io/v2/")
+		require.NoError(t, err)
+		require.Equal(t, Href("https://api.x.io/v2/orders"), actual)
+
+		actual, err = Href("/orders").Resolve("https://api.x.io/v2/")
+		require.NoError(t, err)
+		require.Equal(t, Href("https://api.x.io/orders"), actual)
+	})
+
+	t.Run("relative base", func(t *testing.T) {
+		_, err := Href("g").Resolve("/b/c")
+		require.ErrorIs(t, err, ErrRelativeBaseHref)
+	})
+
+	t.Run("invalid base", func(t *testing.T) {
+		_, err := Href("g").Resolve("http://a/%zz")
+		require.Error(t, err)
+	})
+
+	t.Run("invalid href", func(t *testing.T) {
+		_, err := Href("%zz").Resolve(base)
+		require.Error(t, err)
+	})
+}
+
+func TestHrefRelative(t *testing.T) {
+	type spec struct {
+		href     string
+		base     string
+		expected string
+	}
+
+	specs := map[string]spec{
+		"same host":             {"https://api.example.com/orders/42", "https://api.example.com", "/orders/42"},
+		"base with slash":       {"https://api.example.com/orders", "https://api.example.com/", "/orders"},
+		"host only":             {"https://api.example.com", "https://api.example.com", "/"},
+		"case insensitive host": {"HTTPS://API.example.com/orders", "https://api.example.com", "/orders"},
+		"query and fragment":    {"https://api.example.com/orders?page=2#top", "https://api.example.com", "/orders?page=2#top"},
+		"base with path":        {"https://api.x.io/v2/orders", "https://api.x.io/v2", "/orders"},
+		"base path exact":       {"https://api.x.io/v2", "https://api.x.io/v2/", "/"},
+		"base path partial":     {"https://api.x.io/v20/orders", "https://api.x.io/v2", "https://api.x.io/v20/orders"},
+		"different host":        {"https://example.com/orders", "https://api.example.com", "https://example.com/orders"},
+		"different scheme":      {"http://api.example.com/orders", "https://api.example.com", "http://api.example.com/orders"},
+		"different port":        {"https://api.example.com:8443/orders", "https://api.example.com", "https://api.example.com:8443/orders"},
+		"already relative":      {"/orders", "https://api.example.com", "/orders"},
+		"iana rel":              {"next", "https://api.example.com", "next"},
+		"escaped path":          {"https://api.example.com/a%2Fb", "https://api.example.com", "/a%2Fb"},
+	}
+
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, Href(spec.expected), Href(spec.href).Relative(Href(spec.base)))
+		})
+	}
+
+	t.Run("inverse of WithBaseHref", func(t *testing.T) {
+		h := Href("/orders/42?expand=items")
+		require.Equal(t, h, h.WithBaseHref("https://api.x.io/v2").Relative("https://api.x.io/v2"))
+	})
+}
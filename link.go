@@ -27,3 +27,37 @@ func (l Link) WithBaseHref(base Href) Link {
 		Class: l.Class,
 	}
 }
+
+// WithoutBaseHref returns a copy of this link that removes the supplied base
+// href from the href and rels. This is the inverse of WithBaseHref.
+func (l Link) WithoutBaseHref(base Href) Link {
+	return Link{
+		Rel:   l.Rel.WithoutBaseHref(base),
+		Href:  l.Href.Relative(base),
+		Type:  l.Type,
+		Title: l.Title,
+		Class: l.Class,
+	}
+}
+
+// MapHrefs returns a copy of this link with the href and rels replaced by the
+// result of the mapper.
+func (l Link) MapHrefs(fn HrefMapper) (Link, error) {
+	rel, err := l.Rel.MapHrefs(HrefKindLinkRel, fn)
+	if err != nil {
+		return Link{}, err
+	}
+
+	href, err := fn(HrefKindLink, l.Href)
+	if err != nil {
+		return Link{}, err
+	}
+
+	return Link{
+		Rel:   rel,
+		Href:  href,
+		Type:  l.Type,
+		Title: l.Title,
+		Class: l.Class,
+	}, nil
+}
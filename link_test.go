@@ -37,3 +37,16 @@ func TestLinkWithBaseHref(t *testing.T) {
 	actual := l.WithBaseHref("https://api.example.com")
 	require.EqualValues(t, expected, actual)
 }
+
+func TestLinkWithoutBaseHref(t *testing.T) {
+	l := Link{
+		Href: "https://api.example.com/",
+		Rel:  Rels{"self", "https://api.example.com/rels/custom"},
+	}
+	expected := Link{
+		Href: "/",
+		Rel:  Rels{"self", "/rels/custom"},
+	}
+	actual := l.WithoutBaseHref("https://api.example.com")
+	require.EqualValues(t, expected, actual)
+}
@@ -0,0 +1,247 @@
+package siren
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Marshaler is implemented by types that can build their own entity, which
+// takes precedence over struct tags.
+type Marshaler interface {
+	MarshalSiren() (Entity, error)
+}
+
+// Linker is implemented by types that supply links for their entity.
+type Linker interface {
+	Links() []Link
+}
+
+// Actioner is implemented by types that supply actions for their entity.
+type Actioner interface {
+	Actions() []Action
+}
+
+var entityType = reflect.TypeOf(Entity{})
+
+// Marshal builds an entity from a struct (or pointer to one), using the siren
+// struct tags of its fields. (see below)
+//
+// Untagged fields become properties named the same way encoding/json would
+// name them, respecting the json tag's name and omitempty option. The fields of
+// embedded structs are flattened into the entity. Property values are used
+// as-is, so they are encoded to JSON along with the entity.
+//
+// The siren tag is a kind optionally followed by a name and options:
+//
+//	Name    string  `siren:"prop,name"`           // property "name"
+//	Notes   string  `siren:"prop,notes,omitempty"` // skipped when empty
+//	Label   string  `siren:"title"`               // the entity title
+//	Kind    string  `siren:"class"`               // added to the entity class
+//	Items   []Item  `siren:"entity,rel=item"`     // sub-entities with rel "item"
+//	Secret  string  `siren:"-"`                   // skipped
+//
+// Sub-entities are marshaled recursively, and can be given extra classes with
+// the class option. (eg: `siren:"entity,rel=item,class=order"`) Nil pointers
+// and empty slices of sub-entities are skipped.
+//
+// Links and actions are supplied by implementing Linker and Actioner. Types
+// that implement Marshaler build their entity themselves.
+func Marshal(v any) (Entity, error) {
+	return marshalValue(reflect.ValueOf(v))
+}
+
+func marshalValue(rv reflect.Value) (Entity, error) {
+	if !rv.IsValid() {
+		return Entity{}, fmt.Errorf("siren: cannot marshal nil")
+	}
+
+	if e, ok, err := marshalHook(rv); ok {
+		return e, err
+	}
+
+	sv := rv
+	for sv.Kind() == reflect.Pointer || sv.Kind() == reflect.Interface {
+		if sv.IsNil() {
+			return Entity{}, fmt.Errorf("siren: cannot marshal nil %s", rv.Type())
+		}
+		sv = sv.Elem()
+	}
+
+	if sv.Type() == entityType {
+		return sv.Interface().(Entity), nil
+	}
+	if sv.Kind() != reflect.Struct {
+		return Entity{}, fmt.Errorf("siren: cannot marshal %s, expected a struct", sv.Type())
+	}
+
+	fields, err := structFields(sv.Type())
+	if err != nil {
+		return Entity{}, err
+	}
+
+	var e Entity
+	for _, f := range fields {
+		fv, ok := fieldByIndexValue(sv, f.index)
+		if !ok {
+			continue
+		}
+
+		switch f.kind {
+		case fieldProperty:
+			if f.omitEmpty && isEmptyValue(fv) {
+				continue
+			}
+			if e.Properties == nil {
+				e.Properties = Properties{}
+			}
+			e.Properties[f.name] = fv.Interface()
+
+		case fieldTitle:
+			if fv.Kind() != reflect.String {
+				return Entity{}, fmt.Errorf("siren: title field %s must be a string", f.name)
+			}
+			e.Title = fv.String()
+
+		case fieldClass:
+			classes, err := classNames(fv)
+			if err != nil {
+				return Entity{}, fmt.Errorf("siren: class field %s: %w", f.name, err)
+			}
+			e.Class = append(e.Class, classes...)
+
+		case fieldEntity:
+			entities, err := marshalEntities(fv, f)
+			if err != nil {
+				return Entity{}, err
+			}
+			e.Entities = append(e.Entities, entities...)
+		}
+	}
+
+	if l, ok := asInterface[Linker](rv); ok {
+		e.Links = append(e.Links, l.Links()...)
+	}
+	if a, ok := asInterface[Actioner](rv); ok {
+		e.Actions = append(e.Actions, a.Actions()...)
+	}
+
+	return e, nil
+}
+
+// marshalHook uses the Marshaler implementation of the value, if there is one.
+func marshalHook(rv reflect.Value) (Entity, bool, error) {
+	m, ok := asInterface[Marshaler](rv)
+	if !ok {
+		return Entity{}, false, nil
+	}
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return Entity{}, true, fmt.Errorf("siren: cannot marshal nil %s", rv.Type())
+	}
+	e, err := m.MarshalSiren()
+	return e, true, err
+}
+
+// marshalEntities marshals a field holding one sub-entity, or a slice of them.
+func marshalEntities(fv reflect.Value, f structField) ([]EmbeddedEntity, error) {
+	if fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array {
+		var entities []EmbeddedEntity
+		for i := 0; i < fv.Len(); i++ {
+			embed, ok, err := marshalEntity(fv.Index(i), f)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				entities = append(entities, embed)
+			}
+		}
+		return entities, nil
+	}
+
+	embed, ok, err := marshalEntity(fv, f)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return []EmbeddedEntity{embed}, nil
+}
+
+func marshalEntity(fv reflect.Value, f structField) (EmbeddedEntity, bool, error) {
+	if (fv.Kind() == reflect.Pointer || fv.Kind() == reflect.Interface) && fv.IsNil() {
+		return EmbeddedEntity{}, false, nil
+	}
+
+	// already built sub-entities are used as-is, other than the rel and class
+	if embed, ok := fv.Interface().(EmbeddedEntity); ok {
+		embed.Rel = append(append(Rels(nil), f.rels...), embed.Rel...)
+		embed.Class = append(embed.Class, f.classes...)
+		return embed, true, nil
+	}
+
+	e, err := marshalValue(fv)
+	if err != nil {
+		return EmbeddedEntity{}, false, fmt.Errorf("siren: entity field %s: %w", f.name, err)
+	}
+	e.Class = append(e.Class, f.classes...)
+
+	return EmbeddedRepresentation{Entity: e, Rel: f.rels}.EmbeddedEntity(), true, nil
+}
+
+func classNames(fv reflect.Value) ([]string, error) {
+	switch fv.Kind() {
+	case reflect.String:
+		if fv.String() == "" {
+			return nil, nil
+		}
+		return []string{fv.String()}, nil
+
+	case reflect.Slice, reflect.Array:
+		if fv.Type().Elem().Kind() != reflect.String {
+			break
+		}
+		var classes []string
+		for i := 0; i < fv.Len(); i++ {
+			if s := fv.Index(i).String(); s != "" {
+				classes = append(classes, s)
+			}
+		}
+		return classes, nil
+	}
+
+	return nil, fmt.Errorf("must be a string or slice of strings, got %s", fv.Type())
+}
+
+// asInterface finds an implementation of T on the value, or on a pointer to it
+// when the value is addressable.
+func asInterface[T any](rv reflect.Value) (T, bool) {
+	if rv.CanInterface() {
+		if t, ok := rv.Interface().(T); ok {
+			return t, true
+		}
+	}
+	if rv.Kind() != reflect.Pointer && rv.CanAddr() {
+		if t, ok := rv.Addr().Interface().(T); ok {
+			return t, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// isEmptyValue matches the definition of empty used by encoding/json for the
+// omitempty option.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+package siren_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/dominicbarnes/go-siren"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Timestamps struct {
+	Created string `json:"created"`
+	Updated string `json:"updated,omitempty"`
+}
+
+type OrderItem struct {
+	Kind     string `siren:"class"`
+	Code     string `siren:"prop,code"`
+	Quantity int    `json:"quantity"`
+}
+
+type Customer struct {
+	Name string `json:"name"`
+}
+
+type Order struct {
+	Timestamps
+	ID       int         `siren:"prop,orderNumber"`
+	Status   string      `json:"status"`
+	Notes    string      `siren:"prop,notes,omitempty"`
+	Label    string      `siren:"title"`
+	Classes  []string    `siren:"class"`
+	Items    []OrderItem `siren:"entity,rel=item,class=item"`
+	Customer *Customer   `siren:"entity,rel=customer"`
+	Internal string      `siren:"-"`
+	Ignored  string      `json:"-"`
+	private  string
+}
+
+func (o *Order) Links() []Link {
+	return []Link{{Rel: Rels{"self"}, Href: "/orders/42"}}
+}
+
+func (o *Order) Actions() []Action {
+	return []Action{{Name: "cancel", Href: "/orders/42", Method: "DELETE"}}
+}
+
+func TestMarshal(t *testing.T) {
+	order := &Order{
+		Timestamps: Timestamps{Created: "2024-01-02"},
+		ID:         42,
+		Status:     "pending",
+		Label:      "Order #42",
+		Classes:    []string{"order"},
+		Items:      []OrderItem{{Kind: "product", Code: "ABC-123", Quantity: 2}},
+		Customer:   &Customer{Name: "Jane"},
+		Internal:   "secret",
+		Ignored:    "secret",
+		private:    "secret",
+	}
+
+	e, err := Marshal(order)
+	require.NoError(t, err)
+	require.Equal(t, Entity{
+		Class: Classes{"order"},
+		Title: "Order #42",
+		Properties: Properties{
+			"created":     "2024-01-02",
+			"orderNumber": 42,
+			"status":      "pending",
+		},
+		Entities: []EmbeddedEntity{
+			EmbeddedRepresentation{
+				Entity: Entity{
+					Class:      Classes{"product", "item"},
+					Properties: Properties{"code": "ABC-123", "quantity": 2},
+				},
+				Rel: Rels{"item"},
+			}.EmbeddedEntity(),
+			EmbeddedRepresentation{
+				Entity: Entity{Properties: Properties{"name": "Jane"}},
+				Rel:    Rels{"customer"},
+			}.EmbeddedEntity(),
+		},
+		Links:   []Link{{Rel: Rels{"self"}, Href: "/orders/42"}},
+		Actions: []Action{{Name: "cancel", Href: "/orders/42", Method: "DELETE"}},
+	}, e)
+	require.NoError(t, e.Validate())
+}
+
+func TestMarshalValue(t *testing.T) {
+	// the hooks have pointer receivers, so they are not used for values
+	e, err := Marshal(Order{ID: 42})
+	require.NoError(t, err)
+	require.Empty(t, e.Links)
+	require.Empty(t, e.Actions)
+	require.Empty(t, e.Entities)
+}
+
+type shadowed struct {
+	Timestamps
+	Created int `json:"created"`
+}
+
+func TestMarshalShadowedProperty(t *testing.T) {
+	e, err := Marshal(shadowed{Timestamps: Timestamps{Created: "yesterday"}, Created: 1})
+	require.NoError(t, err)
+	require.Equal(t, Properties{"created": 1}, e.Properties)
+}
+
+type Left struct {
+	Name  string
+	Code  string
+	Color string `json:"color"`
+}
+
+type Right struct {
+	Name  string
+	Code  string `json:"Code"`
+	Color string
+}
+
+type ambiguous struct {
+	Left
+	Right
+}
+
+func TestMarshalAmbiguousProperty(t *testing.T) {
+	v := ambiguous{
+		Left:  Left{Name: "left", Code: "left", Color: "left"},
+		Right: Right{Name: "right", Code: "right", Color: "right"},
+	}
+
+	// like encoding/json, ambiguous names are dropped unless exactly one of
+	// them is tagged
+	e, err := Marshal(v)
+	require.NoError(t, err)
+	require.Equal(t, Properties{"Code": "right", "color": "left", "Color": "right"}, e.Properties)
+
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Code": "right", "color": "left", "Color": "right"}`, string(b))
+}
+
+type custom struct{}
+
+func (custom) MarshalSiren() (Entity, error) {
+	return Entity{Class: Classes{"custom"}}, nil
+}
+
+func TestMarshalMarshaler(t *testing.T) {
+	e, err := Marshal(custom{})
+	require.NoError(t, err)
+	require.Equal(t, Entity{Class: Classes{"custom"}}, e)
+
+	e, err = Marshal(&Problem{Status: 404})
+	require.NoError(t, err)
+	require.True(t, e.HasClass(ProblemClass))
+}
+
+func TestMarshalJSON(t *testing.T) {
+	e, err := Marshal(&Order{ID: 42, Status: "pending", Customer: &Customer{Name: "Jane"}})
+	require.NoError(t, err)
+
+	b, err := json.Marshal(e)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"properties": {"created": "", "orderNumber": 42, "status": "pending"},
+		"entities": [{"rel": ["customer"], "properties": {"name": "Jane"}}],
+		"links": [{"rel": ["self"], "href": "/orders/42"}],
+		"actions": [{"name": "cancel", "href": "/orders/42", "method": "DELETE"}]
+	}`, string(b))
+}
+
+func TestMarshalErrors(t *testing.T) {
+	specs := map[string]struct {
+		input    any
+		expected string
+	}{
+		"nil": {
+			input:    nil,
+			expected: "siren: cannot marshal nil",
+		},
+		"nil pointer": {
+			input:    (*Customer)(nil),
+			expected: "siren: cannot marshal nil *siren_test.Customer",
+		},
+		"not a struct": {
+			input:    42,
+			expected: "siren: cannot marshal int, expected a struct",
+		},
+		"unknown kind": {
+			input: struct {
+				Name string `siren:"property"`
+			}{},
+			expected: `siren: field Name of struct { Name string "siren:\"property\"" }: unknown kind "property" in siren tag`,
+		},
+		"unknown option": {
+			input: struct {
+				Name string `siren:"prop,name,required"`
+			}{},
+			expected: `siren: field Name of struct { Name string "siren:\"prop,name,required\"" }: unknown option "required" in siren tag`,
+		},
+		"entity without rel": {
+			input: struct {
+				Customer Customer `siren:"entity"`
+			}{},
+			expected: `siren: field Customer of struct { Customer siren_test.Customer "siren:\"entity\"" }: entity requires a rel in siren tag`,
+		},
+		"title not a string": {
+			input: struct {
+				Title int `siren:"title"`
+			}{},
+			expected: "siren: title field Title must be a string",
+		},
+		"invalid sub-entity": {
+			input: struct {
+				Total int `siren:"entity,rel=total"`
+			}{},
+			expected: "siren: entity field Total: siren: cannot marshal int, expected a struct",
+		},
+	}
+
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			_, err := Marshal(spec.input)
+			require.EqualError(t, err, spec.expected)
+		})
+	}
+}
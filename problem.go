@@ -0,0 +1,196 @@
+package siren
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// ProblemClass is the class of entities that describe an error.
+const ProblemClass = "error"
+
+// ProblemMediaType is the media type for problem details as described by
+// RFC 7807.
+const ProblemMediaType = "application/problem+json"
+
+// Problem describes an error in a way that can be sent to clients, either as a
+// siren entity (see Entity) or as RFC 7807 problem details (using json.Marshal)
+// which it is modelled after.
+//
+// Problem implements the error interface, so it can be returned by handlers
+// and recovered with errors.As.
+type Problem struct {
+	// Type identifies the kind of problem, and should point to human-readable
+	// documentation about it.
+	Type Href `json:"type,omitempty"`
+
+	// Title is a short summary of the kind of problem.
+	Title string `json:"title,omitempty"`
+
+	// Status is the HTTP status code for the problem.
+	Status int `json:"status,omitempty"`
+
+	// Detail explains this specific occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+
+	// Instance identifies the resource this occurrence of the problem relates
+	// to.
+	Instance Href `json:"instance,omitempty"`
+
+	// Code is an application-specific error code. (eg: "order_not_found")
+	Code string `json:"code,omitempty"`
+
+	// Errors lists problems with individual fields, such as when user input
+	// fails validation.
+	Errors []FieldProblem `json:"errors,omitempty"`
+}
+
+// FieldProblem describes a problem with a single field of user input.
+type FieldProblem struct {
+	Field   string `json:"field"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Error implements the error interface. (eg: "404 Not Found: order 42 does not
+// exist")
+func (p *Problem) Error() string {
+	title := p.Title
+	if title == "" {
+		title = http.StatusText(p.Status)
+	}
+
+	msg := title
+	if p.Status != 0 {
+		msg = strconv.Itoa(p.Status) + " " + msg
+	}
+	if p.Detail != "" {
+		if msg == "" {
+			return p.Detail
+		}
+		msg += ": " + p.Detail
+	}
+	if msg == "" {
+		return "problem"
+	}
+	return msg
+}
+
+// Entity represents the problem as a siren entity with the "error" class. The
+// status, code, detail (as "message") and field errors are set as properties,
+// Type becomes a "help" link and Instance becomes the "self" link.
+func (p *Problem) Entity() Entity {
+	props := Properties{}
+	if p.Status != 0 {
+		props["status"] = p.Status
+	}
+	if p.Code != "" {
+		props["code"] = p.Code
+	}
+	if p.Detail != "" {
+		props["message"] = p.Detail
+	}
+	if len(p.Errors) > 0 {
+		props["errors"] = p.Errors
+	}
+
+	var links []Link
+	if p.Instance != "" {
+		links = append(links, Link{Rel: Rels{"self"}, Href: p.Instance})
+	}
+	if p.Type != "" {
+		links = append(links, Link{Rel: Rels{"help"}, Href: p.Type})
+	}
+
+	return Entity{
+		Class:      Classes{ProblemClass},
+		Title:      p.Title,
+		Properties: props,
+		Links:      links,
+	}
+}
+
+// MarshalSiren implements Marshaler, using Entity.
+func (p *Problem) MarshalSiren() (Entity, error) {
+	return p.Entity(), nil
+}
+
+// ParseProblem converts an entity with the "error" class back into a Problem,
+// returning false for any other entity.
+//
+// Besides the properties written by Problem.Entity, the RFC 7807 member names
+// "detail", "title", "type" and "instance" are accepted as properties too.
+func ParseProblem(e Entity) (*Problem, bool) {
+	if !e.HasClass(ProblemClass) {
+		return nil, false
+	}
+
+	p := &Problem{
+		Title:  e.Title,
+		Status: intProperty(e.Properties["status"]),
+		Code:   stringProperty(e.Properties["code"]),
+		Detail: stringProperty(e.Properties["message"]),
+	}
+
+	if p.Title == "" {
+		p.Title = stringProperty(e.Properties["title"])
+	}
+	if p.Detail == "" {
+		p.Detail = stringProperty(e.Properties["detail"])
+	}
+
+	if link, ok := e.LinkByRel("self"); ok {
+		p.Instance = link.Href
+	} else {
+		p.Instance = Href(stringProperty(e.Properties["instance"]))
+	}
+
+	if link, ok := e.LinkByRel("help"); ok {
+		p.Type = link.Href
+	} else if link, ok := e.LinkByRel("describedby"); ok {
+		p.Type = link.Href
+	} else {
+		p.Type = Href(stringProperty(e.Properties["type"]))
+	}
+
+	if errs, ok := e.Properties["errors"]; ok {
+		// the errors are round-tripped through JSON, since they may be either
+		// []FieldProblem or the []any produced by decoding a response
+		if b, err := json.Marshal(errs); err == nil {
+			if err := json.Unmarshal(b, &p.Errors); err != nil {
+				p.Errors = nil
+			}
+		}
+	}
+
+	return p, true
+}
+
+func stringProperty(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	return fmt.Sprint(v)
+}
+
+func intProperty(v any) int {
+	switch v := v.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case json.Number:
+		n, _ := v.Int64()
+		return int(n)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+	return 0
+}
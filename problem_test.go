@@ -0,0 +1,153 @@
+package siren_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/dominicbarnes/go-siren"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProblemError(t *testing.T) {
+	specs := map[string]struct {
+		problem  Problem
+		expected string
+	}{
+		"empty":           {problem: Problem{}, expected: "problem"},
+		"status":          {problem: Problem{Status: 404}, expected: "404 Not Found"},
+		"title":           {problem: Problem{Status: 404, Title: "Order Not Found"}, expected: "404 Order Not Found"},
+		"detail":          {problem: Problem{Status: 404, Detail: "order 42 does not exist"}, expected: "404 Not Found: order 42 does not exist"},
+		"detail only":     {problem: Problem{Detail: "order 42 does not exist"}, expected: "order 42 does not exist"},
+		"title no status": {problem: Problem{Title: "Order Not Found"}, expected: "Order Not Found"},
+	}
+
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			require.EqualError(t, &spec.problem, spec.expected)
+		})
+	}
+}
+
+func TestProblemErrorsAs(t *testing.T) {
+	err := fmt.Errorf("placing order: %w", &Problem{Status: 409})
+
+	var p *Problem
+	require.True(t, errors.As(err, &p))
+	require.Equal(t, 409, p.Status)
+}
+
+func TestProblemEntity(t *testing.T) {
+	p := &Problem{
+		Type:     "/docs/errors/invalid-order",
+		Title:    "Invalid Order",
+		Status:   422,
+		Detail:   "the order could not be placed",
+		Instance: "/orders/42",
+		Code:     "invalid_order",
+		Errors:   []FieldProblem{{Field: "quantity", Code: "min", Message: "must be positive"}},
+	}
+
+	e := p.Entity()
+	require.Equal(t, Entity{
+		Class: Classes{"error"},
+		Title: "Invalid Order",
+		Properties: Properties{
+			"status":  422,
+			"code":    "invalid_order",
+			"message": "the order could not be placed",
+			"errors":  p.Errors,
+		},
+		Links: []Link{
+			{Rel: Rels{"self"}, Href: "/orders/42"},
+			{Rel: Rels{"help"}, Href: "/docs/errors/invalid-order"},
+		},
+	}, e)
+	require.NoError(t, e.Validate())
+
+	// round-trip through JSON like a client would
+	b, err := json.Marshal(e)
+	require.NoError(t, err)
+	var decoded Entity
+	require.NoError(t, json.Unmarshal(b, &decoded))
+
+	parsed, ok := ParseProblem(decoded)
+	require.True(t, ok)
+	require.Equal(t, p, parsed)
+}
+
+func TestParseProblem(t *testing.T) {
+	specs := map[string]struct {
+		input    Entity
+		expected *Problem
+	}{
+		"not an error": {
+			input: Entity{Class: Classes{"order"}},
+		},
+		"empty": {
+			input:    Entity{Class: Classes{"error"}},
+			expected: &Problem{},
+		},
+		"problem details properties": {
+			input: Entity{
+				Class: Classes{"error"},
+				Properties: Properties{
+					"type":     "/docs/errors/out-of-credit",
+					"title":    "You do not have enough credit.",
+					"status":   json.Number("403"),
+					"detail":   "Your current balance is 30, but that costs 50.",
+					"instance": "/account/12345/msgs/abc",
+				},
+			},
+			expected: &Problem{
+				Type:     "/docs/errors/out-of-credit",
+				Title:    "You do not have enough credit.",
+				Status:   403,
+				Detail:   "Your current balance is 30, but that costs 50.",
+				Instance: "/account/12345/msgs/abc",
+			},
+		},
+		"describedby link": {
+			input: Entity{
+				Class: Classes{"error"},
+				Links: []Link{{Rel: Rels{"describedby"}, Href: "/docs/errors/conflict"}},
+			},
+			expected: &Problem{Type: "/docs/errors/conflict"},
+		},
+		"malformed errors": {
+			input: Entity{
+				Class:      Classes{"error"},
+				Properties: Properties{"errors": "quantity is invalid"},
+			},
+			expected: &Problem{},
+		},
+	}
+
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			p, ok := ParseProblem(spec.input)
+			require.Equal(t, spec.expected != nil, ok)
+			require.Equal(t, spec.expected, p)
+		})
+	}
+}
+
+func TestProblemJSON(t *testing.T) {
+	p := Problem{
+		Type:   "https://example.com/probs/out-of-credit",
+		Title:  "You do not have enough credit.",
+		Status: 403,
+		Code:   "out_of_credit",
+	}
+
+	b, err := json.Marshal(p)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"type": "https://example.com/probs/out-of-credit",
+		"title": "You do not have enough credit.",
+		"status": 403,
+		"code": "out_of_credit"
+	}`, string(b))
+}
@@ -1,5 +1,7 @@
 package siren
 
+import "strings"
+
 // Rels is a collection of link relations. They can either be short names as
 // defined by the IANA, or full URLs that are application-specific.
 type Rels []Href
@@ -13,3 +15,50 @@ func (r Rels) WithBaseHref(base Href) Rels {
 	}
 	return rels
 }
+
+// Contains reports whether the given rel is in this collection. IANA short
+// names are compared case-insensitively, while URL rels must match exactly.
+func (r Rels) Contains(rel Href) bool {
+	for _, candidate := range r {
+		if candidate == rel {
+			return true
+		} else if isShortRel(rel) && strings.EqualFold(string(candidate), string(rel)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isShortRel reports whether a rel is a short name (eg: "next") rather than
+// a URL.
+func isShortRel(rel Href) bool {
+	return !strings.ContainsAny(string(rel), ":/")
+}
+
+// WithoutBaseHref removes the given base href from all the rels that are
+// absolute URLs under it. This is the inverse of WithBaseHref.
+func (r Rels) WithoutBaseHref(base Href) Rels {
+	rels := make(Rels, len(r))
+	for x, rel := range r {
+		rels[x] = rel.Relative(base)
+	}
+	return rels
+}
+
+// MapHrefs returns a copy of these rels with each one replaced by the result of
+// the mapper, which is called with the given kind. Nil rels are returned as nil.
+func (r Rels) MapHrefs(kind HrefKind, fn HrefMapper) (Rels, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	rels := make(Rels, len(r))
+	for x, rel := range r {
+		mapped, err := fn(kind, rel)
+		if err != nil {
+			return nil, err
+		}
+		rels[x] = mapped
+	}
+	return rels, nil
+}
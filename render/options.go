@@ -0,0 +1,23 @@
+package render
+
+import siren "github.com/dominicbarnes/go-siren"
+
+// RendererOption is used to configure a Renderer when calling New.
+type RendererOption func(*Renderer)
+
+// WithBaseHref sets a fixed base href to apply to entities, rather than
+// deriving one from each request.
+func WithBaseHref(base siren.Href) RendererOption {
+	return func(r *Renderer) {
+		r.base = base
+	}
+}
+
+// WithForwardedHeaders trusts the X-Forwarded-Proto and X-Forwarded-Host
+// headers when deriving the base href from a request. Only enable this when the
+// server is behind a proxy that sets these headers.
+func WithForwardedHeaders() RendererOption {
+	return func(r *Renderer) {
+		r.trustForwarded = true
+	}
+}
@@ -0,0 +1,243 @@
+// Package render writes siren entities to HTTP responses, negotiating the
+// media type with the client.
+package render
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	siren "github.com/dominicbarnes/go-siren"
+)
+
+// ErrNotAcceptable is used when the request's Accept header does not allow any
+// of the media types that entities can be written as.
+var ErrNotAcceptable = errors.New("not acceptable")
+
+// JSONMediaType is the fallback media type for clients that do not accept
+// siren, but do accept plain JSON.
+const JSONMediaType = "application/json"
+
+// Renderer writes siren entities to HTTP responses.
+type Renderer struct {
+	base           siren.Href
+	trustForwarded bool
+}
+
+// New creates a new renderer, applying any options supplied.
+func New(opts ...RendererOption) *Renderer {
+	r := new(Renderer)
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+var defaultRenderer = New()
+
+// Render writes the entity using a renderer with the default options. See
+// Renderer.Render for details.
+func Render(w http.ResponseWriter, req *http.Request, status int, e siren.Entity) error {
+	return defaultRenderer.Render(w, req, status, e)
+}
+
+// Error writes the error using a renderer with the default options. See
+// Renderer.Error for details.
+func Error(w http.ResponseWriter, req *http.Request, err error) error {
+	return defaultRenderer.Error(w, req, err)
+}
+
+// Render writes the entity to the response with the given status code. When
+// status is 0, http.StatusOK is used.
+//
+// The media type is negotiated using the request's Accept header: siren is
+// preferred, with application/json as a fallback for clients that do not
+// accept siren. When neither is acceptable, a 406 Not Acceptable response is
+// written and ErrNotAcceptable is returned.
+//
+// Before writing, the base href of the request is applied to the entity with
+// siren.Entity.WithBaseHref, so servers can use hrefs such as "/orders/42".
+func (r *Renderer) Render(w http.ResponseWriter, req *http.Request, status int, e siren.Entity) error {
+	w.Header().Add("vary", "accept")
+
+	mediaType, ok := Negotiate(req.Header.Get("accept"))
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+		return ErrNotAcceptable
+	}
+
+	return write(w, mediaType, status, e.WithBaseHref(r.BaseHref(req)))
+}
+
+// Error writes the error to the response as a problem. When err is (or wraps)
+// a *siren.Problem, it is used as-is, otherwise a generic 500 Internal Server
+// Error problem is written so that internal details are never leaked to
+// clients. When the problem has no status, 500 is used.
+//
+// The problem is written as a siren entity (see siren.Problem.Entity) unless
+// the request prefers application/problem+json or application/json, in which
+// case RFC 7807 problem details are written instead. Since something should
+// always be sent back for an error, siren is used when none of these are
+// acceptable.
+func (r *Renderer) Error(w http.ResponseWriter, req *http.Request, err error) error {
+	w.Header().Add("vary", "accept")
+
+	var p siren.Problem
+	var perr *siren.Problem
+	if errors.As(err, &perr) {
+		p = *perr
+	}
+	if p.Status == 0 {
+		p.Status = http.StatusInternalServerError
+	}
+	if perr == nil {
+		p.Title = http.StatusText(p.Status)
+	}
+
+	base := r.BaseHref(req)
+
+	mediaType, ok := negotiate(req.Header.Get("accept"), siren.MediaType, siren.ProblemMediaType, JSONMediaType)
+	if !ok || mediaType == siren.MediaType {
+		return write(w, siren.MediaType, p.Status, p.Entity().WithBaseHref(base))
+	}
+
+	p.Type = p.Type.WithBaseHref(base)
+	p.Instance = p.Instance.WithBaseHref(base)
+	return write(w, mediaType, p.Status, p)
+}
+
+// write encodes v as JSON and writes it to the response with the given media
+// type and status.
+func write(w http.ResponseWriter, mediaType string, status int, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return err
+	}
+
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	w.Header().Set("content-type", mediaType)
+	w.WriteHeader(status)
+	_, err = w.Write(body)
+	return err
+}
+
+// BaseHref determines the base href for hrefs in responses to the given
+// request. A base configured with WithBaseHref is always used when set,
+// otherwise it is derived from the scheme and host of the request, taking
+// X-Forwarded-Proto and X-Forwarded-Host into account when configured with
+// WithForwardedHeaders.
+func (r *Renderer) BaseHref(req *http.Request) siren.Href {
+	if r.base != "" {
+		return r.base
+	}
+
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	host := req.Host
+
+	if r.trustForwarded {
+		if proto := forwarded(req.Header.Get("x-forwarded-proto")); proto != "" {
+			scheme = strings.ToLower(proto)
+		}
+		if fhost := forwarded(req.Header.Get("x-forwarded-host")); fhost != "" {
+			host = fhost
+		}
+	}
+
+	return siren.Href(scheme + "://" + host)
+}
+
+// forwarded returns the first value of a comma-separated forwarding header,
+// which is the one added by the proxy closest to the client.
+func forwarded(value string) string {
+	first, _, _ := strings.Cut(value, ",")
+	return strings.TrimSpace(first)
+}
+
+// Negotiate picks the media type to write an entity as, given the value of an
+// Accept header. Quality values and wildcards are supported, and siren is
+// preferred when both siren and application/json are equally acceptable. An
+// empty header accepts anything. When neither is acceptable, false is returned.
+func Negotiate(accept string) (string, bool) {
+	return negotiate(accept, siren.MediaType, JSONMediaType)
+}
+
+// negotiate picks the most acceptable of the candidate media types, which are
+// listed in order of preference.
+func negotiate(accept string, candidates ...string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return candidates[0], true
+	}
+
+	ranges := parseAccept(accept)
+
+	best, bestQ := "", 0.0
+	for _, candidate := range candidates {
+		if q := quality(ranges, candidate); q > bestQ {
+			best, bestQ = candidate, q
+		}
+	}
+
+	return best, best != ""
+}
+
+type mediaRange struct {
+	mediaType string
+	q         float64
+}
+
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+
+		ranges = append(ranges, mediaRange{mediaType: mediaType, q: q})
+	}
+	return ranges
+}
+
+// quality finds the quality value of the most specific media range matching
+// the media type. (eg: "application/json" beats "application/*" which beats
+// "*/*")
+func quality(ranges []mediaRange, mediaType string) float64 {
+	typ, _, _ := strings.Cut(mediaType, "/")
+
+	q, specificity := 0.0, -1
+	for _, r := range ranges {
+		var s int
+		switch r.mediaType {
+		case mediaType:
+			s = 2
+		case typ + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		default:
+			continue
+		}
+
+		if s > specificity {
+			q, specificity = r.q, s
+		}
+	}
+	return q
+}
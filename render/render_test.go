@@ -0,0 +1,205 @@
+package render_test
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	siren "github.com/dominicbarnes/go-siren"
+	"github.com/dominicbarnes/go-siren/render"
+
+	"github.com/stretchr/testify/require"
+)
+
+var entity = siren.Entity{
+	Class: siren.Classes{"order"},
+	Links: []siren.Link{{Rel: siren.Rels{"self"}, Href: "/orders/42"}},
+}
+
+func TestRender(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://api.example.com/orders/42", nil)
+	w := httptest.NewRecorder()
+
+	require.NoError(t, render.Render(w, req, http.StatusCreated, entity))
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, siren.MediaType, w.Header().Get("content-type"))
+	require.Equal(t, "accept", w.Header().Get("vary"))
+	require.JSONEq(t, `{
+		"class": ["order"],
+		"links": [{"rel": ["self"], "href": "http://api.example.com/orders/42"}]
+	}`, w.Body.String())
+}
+
+func TestRenderDefaultStatus(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+	w := httptest.NewRecorder()
+
+	require.NoError(t, render.Render(w, req, 0, entity))
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRenderNegotiation(t *testing.T) {
+	type spec struct {
+		accept   string
+		expected string
+	}
+
+	specs := map[string]spec{
+		"empty":             {accept: "", expected: siren.MediaType},
+		"siren":             {accept: siren.MediaType, expected: siren.MediaType},
+		"json":              {accept: "application/json", expected: "application/json"},
+		"any":               {accept: "*/*", expected: siren.MediaType},
+		"application range": {accept: "application/*", expected: siren.MediaType},
+		"prefer json":       {accept: siren.MediaType + ";q=0.5, application/json", expected: "application/json"},
+		"prefer siren":      {accept: "application/json;q=0.5, " + siren.MediaType, expected: siren.MediaType},
+		"siren excluded":    {accept: siren.MediaType + ";q=0, */*", expected: "application/json"},
+		"browser":           {accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", expected: siren.MediaType},
+	}
+
+	for name, s := range specs {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+			req.Header.Set("accept", s.accept)
+			w := httptest.NewRecorder()
+
+			require.NoError(t, render.Render(w, req, http.StatusOK, entity))
+			require.Equal(t, s.expected, w.Header().Get("content-type"))
+		})
+	}
+}
+
+func TestRenderNotAcceptable(t *testing.T) {
+	specs := map[string]string{
+		"html":       "text/html",
+		"q zero":     siren.MediaType + ";q=0, application/json;q=0",
+		"text range": "text/*",
+	}
+
+	for name, accept := range specs {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+			req.Header.Set("accept", accept)
+			w := httptest.NewRecorder()
+
+			require.ErrorIs(t, render.Render(w, req, http.StatusOK, entity), render.ErrNotAcceptable)
+			require.Equal(t, http.StatusNotAcceptable, w.Code)
+		})
+	}
+}
+
+func TestRendererBaseHref(t *testing.T) {
+	type spec struct {
+		opts     []render.RendererOption
+		tls      bool
+		header   http.Header
+		expected siren.Href
+	}
+
+	forwarded := http.Header{
+		"X-Forwarded-Proto": {"https"},
+		"X-Forwarded-Host":  {"public.example.com, proxy.internal"},
+	}
+
+	specs := map[string]spec{
+		"plain": {
+			expected: "http://api.example.com",
+		},
+		"tls": {
+			tls:      true,
+			expected: "https://api.example.com",
+		},
+		"forwarded headers ignored": {
+			header:   forwarded,
+			expected: "http://api.example.com",
+		},
+		"forwarded headers trusted": {
+			opts:     []render.RendererOption{render.WithForwardedHeaders()},
+			header:   forwarded,
+			expected: "https://public.example.com",
+		},
+		"fixed base": {
+			opts:     []render.RendererOption{render.WithBaseHref("https://example.com/api")},
+			header:   forwarded,
+			expected: "https://example.com/api",
+		},
+	}
+
+	for name, s := range specs {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://api.example.com/orders/42", nil)
+			if s.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+			for k, v := range s.header {
+				req.Header[k] = v
+			}
+
+			require.Equal(t, s.expected, render.New(s.opts...).BaseHref(req))
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	problem := &siren.Problem{
+		Type:     "/docs/errors/invalid-order",
+		Title:    "Invalid Order",
+		Status:   http.StatusUnprocessableEntity,
+		Detail:   "the order could not be placed",
+		Instance: "/orders/42",
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "http://api.example.com/orders", nil)
+	w := httptest.NewRecorder()
+
+	require.NoError(t, render.Error(w, req, fmt.Errorf("placing order: %w", problem)))
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.Equal(t, siren.MediaType, w.Header().Get("content-type"))
+	require.JSONEq(t, `{
+		"class": ["error"],
+		"title": "Invalid Order",
+		"properties": {"status": 422, "message": "the order could not be placed"},
+		"links": [
+			{"rel": ["self"], "href": "http://api.example.com/orders/42"},
+			{"rel": ["help"], "href": "http://api.example.com/docs/errors/invalid-order"}
+		]
+	}`, w.Body.String())
+}
+
+func TestErrorProblemJSON(t *testing.T) {
+	specs := map[string]string{
+		"problem+json": siren.ProblemMediaType,
+		"json":         "application/json",
+	}
+
+	for name, accept := range specs {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://api.example.com/orders/42", nil)
+			req.Header.Set("accept", accept)
+			w := httptest.NewRecorder()
+
+			err := &siren.Problem{Status: http.StatusNotFound, Instance: "/orders/42"}
+			require.NoError(t, render.Error(w, req, err))
+			require.Equal(t, http.StatusNotFound, w.Code)
+			require.Equal(t, accept, w.Header().Get("content-type"))
+			require.JSONEq(t, `{"status": 404, "instance": "http://api.example.com/orders/42"}`, w.Body.String())
+		})
+	}
+}
+
+func TestErrorInternal(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+	req.Header.Set("accept", "text/html")
+	w := httptest.NewRecorder()
+
+	require.NoError(t, render.Error(w, req, errors.New("database password is hunter2")))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Equal(t, siren.MediaType, w.Header().Get("content-type"))
+	require.JSONEq(t, `{
+		"class": ["error"],
+		"title": "Internal Server Error",
+		"properties": {"status": 500}
+	}`, w.Body.String())
+}
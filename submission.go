@@ -0,0 +1,227 @@
+package siren
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	// ErrUnknownField is used when a submission contains a field that the
+	// action does not declare.
+	ErrUnknownField = errors.New("siren: unknown field")
+
+	// ErrUnsupportedMediaType is used when a submission is sent with a media
+	// type that does not match the action, or that can not be decoded.
+	ErrUnsupportedMediaType = errors.New("siren: unsupported media type")
+)
+
+// multipartMaxMemory is the number of bytes of a multipart body that are kept
+// in memory, with the remainder of any files being stored on disk. This
+// matches the default used by http.Request.FormValue.
+const multipartMaxMemory = 32 << 20
+
+// maxBodySize is the largest urlencoded, text/plain or JSON body that will be
+// read, which matches the limit used by http.Request.ParseForm.
+const maxBodySize = 10 << 20
+
+// Decode reads the data submitted for this action from an incoming request,
+// which is the server-side counterpart to submitting the action with a client.
+//
+// When the action's method is GET, the data is read from the query string.
+// Otherwise the body is decoded according to the action's type, which can be
+// application/x-www-form-urlencoded, multipart/form-data, text/plain or
+// application/json. When the request has a content-type that does not match
+// the action's type, ErrUnsupportedMediaType is returned.
+//
+// Form fields submitted once are strings and fields submitted multiple times
+// are []string, while multipart files are *multipart.FileHeader or
+// []*multipart.FileHeader. Fields using bracket notation, as sent by clients
+// configured with client.WithBracketNotation, become nested maps. (eg:
+// "address[city]=Paris" is decoded as the "address" field) Bodies other than
+// multipart/form-data are limited to 10MB. Field values declared by the action are used as
+// defaults for fields that were not submitted, and fields that the action does
+// not declare are rejected with ErrUnknownField.
+//
+// The data is stored in the value pointed to by v, which can be a
+// *map[string]any or a pointer to a struct. Struct fields are matched the same
+// way Marshal names properties: a name in the siren tag takes precedence over
+// the json tag, which takes precedence over the field name. Fields tagged as
+// anything other than a property are ignored. Values are converted to the
+// field's type. (eg: "42" can be decoded into an int field)
+func (a Action) Decode(r *http.Request, v any) error {
+	data, err := a.submission(r)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range a.Fields {
+		if _, ok := data[f.Name]; !ok && f.Value != nil && f.Value != "" {
+			data[f.Name] = f.Value
+		}
+	}
+
+	for _, key := range sortedKeys(data) {
+		if !a.hasField(key) {
+			return fmt.Errorf("%w: %q", ErrUnknownField, key)
+		}
+	}
+
+	return newAssigner().decodeInto(v, data)
+}
+
+func (a Action) hasField(name string) bool {
+	for _, f := range a.Fields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// submission reads the raw data from the request.
+func (a Action) submission(r *http.Request) (map[string]any, error) {
+	if a.GetMethod() == http.MethodGet {
+		return formData(r.URL.Query())
+	}
+
+	mediaType, _, err := mime.ParseMediaType(a.GetType())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedMediaType, a.GetType())
+	}
+
+	if ct := r.Header.Get("content-type"); ct != "" {
+		got, _, err := mime.ParseMediaType(ct)
+		if err != nil || got != mediaType {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedMediaType, ct)
+		}
+	}
+
+	var body io.Reader = http.MaxBytesReader(nil, r.Body, maxBodySize)
+
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		values, err := url.ParseQuery(string(b))
+		if err != nil {
+			return nil, err
+		}
+		return formData(values)
+
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+			return nil, err
+		}
+		data, err := formData(r.MultipartForm.Value)
+		if err != nil {
+			return nil, err
+		}
+		for key, files := range r.MultipartForm.File {
+			if len(files) == 1 {
+				data[key] = files[0]
+			} else {
+				data[key] = files
+			}
+		}
+		return data, nil
+
+	case "text/plain":
+		values := url.Values{}
+		s := bufio.NewScanner(body)
+		for s.Scan() {
+			if line := strings.TrimSuffix(s.Text(), "\r"); line != "" {
+				key, value, _ := strings.Cut(line, "=")
+				values.Add(key, value)
+			}
+		}
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+		return formData(values)
+
+	case "application/json":
+		var data map[string]any
+		d := json.NewDecoder(body)
+		d.UseNumber()
+		if err := d.Decode(&data); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if err := d.Decode(new(json.RawMessage)); !errors.Is(err, io.EOF) {
+			return nil, errors.New("siren: unexpected data after JSON body")
+		}
+		if data == nil {
+			data = make(map[string]any)
+		}
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedMediaType, mediaType)
+}
+
+// formData converts form values into submission data, where single values
+// become strings and repeated values become []string. Keys using bracket
+// notation become nested maps.
+func formData(values url.Values) (map[string]any, error) {
+	data := make(map[string]any, len(values))
+	for _, key := range sortedKeys(values) {
+		var value any = values[key]
+		if len(values[key]) == 1 {
+			value = values[key][0]
+		}
+
+		name, path := splitBrackets(key)
+		if err := setNested(data, name, path, value); err != nil {
+			return nil, fmt.Errorf("field %q: %w", key, err)
+		}
+	}
+	return data, nil
+}
+
+// splitBrackets splits a key using bracket notation into the name and the
+// nested keys. (eg: "address[geo][lat]" is "address" with ["geo", "lat"])
+// Keys that do not use bracket notation correctly are returned as-is.
+func splitBrackets(key string) (string, []string) {
+	open := strings.IndexByte(key, '[')
+	if open <= 0 {
+		return key, nil
+	}
+
+	var path []string
+	for rest := key[open:]; rest != ""; {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 2 {
+			return key, nil
+		}
+		path = append(path, rest[1:end])
+		rest = rest[end+1:]
+	}
+	return key[:open], path
+}
+
+func setNested(data map[string]any, name string, path []string, value any) error {
+	if len(path) == 0 {
+		if _, ok := data[name]; ok {
+			return errors.New("conflicting values")
+		}
+		data[name] = value
+		return nil
+	}
+
+	if _, ok := data[name]; !ok {
+		data[name] = make(map[string]any)
+	}
+	child, ok := data[name].(map[string]any)
+	if !ok {
+		return errors.New("conflicting values")
+	}
+	return setNested(child, path[0], path[1:], value)
+}
@@ -0,0 +1,378 @@
+package siren_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/dominicbarnes/go-siren"
+	"github.com/dominicbarnes/go-siren/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+var addItem = Action{
+	Name:   "add-item",
+	Href:   "/orders/42/items",
+	Method: http.MethodPost,
+	Fields: []ActionField{
+		{Name: "orderNumber", Type: "hidden", Value: "42"},
+		{Name: "productCode", Type: "text"},
+		{Name: "quantity", Type: "number"},
+	},
+}
+
+func TestActionDecode(t *testing.T) {
+	type spec struct {
+		action      Action
+		method      string
+		target      string
+		contentType string
+		body        string
+		expected    map[string]any
+	}
+
+	specs := map[string]spec{
+		"query": {
+			action:   Action{Name: "search", Href: "/orders", Fields: []ActionField{{Name: "q"}, {Name: "status"}}},
+			method:   http.MethodGet,
+			target:   "/orders?q=pizza&status=open&status=paid",
+			expected: map[string]any{"q": "pizza", "status": []string{"open", "paid"}},
+		},
+		"form": {
+			action:      addItem,
+			contentType: "application/x-www-form-urlencoded",
+			body:        "productCode=ABC-123&quantity=2",
+			expected:    map[string]any{"orderNumber": "42", "productCode": "ABC-123", "quantity": "2"},
+		},
+		"form without content-type": {
+			action:   addItem,
+			body:     "productCode=ABC-123",
+			expected: map[string]any{"orderNumber": "42", "productCode": "ABC-123"},
+		},
+		"form brackets": {
+			action:      Action{Name: "ship", Href: "/orders/42", Method: http.MethodPost, Fields: []ActionField{{Name: "address"}}},
+			contentType: "application/x-www-form-urlencoded",
+			body:        "address%5Bcity%5D=Paris&address%5Bgeo%5D%5Blat%5D=48.85&address%5Blines%5D=a&address%5Blines%5D=b",
+			expected: map[string]any{"address": map[string]any{
+				"city":  "Paris",
+				"geo":   map[string]any{"lat": "48.85"},
+				"lines": []string{"a", "b"},
+			}},
+		},
+		"text": {
+			action:      Action{Name: "note", Href: "/notes", Method: http.MethodPost, Type: "text/plain", Fields: []ActionField{{Name: "a"}, {Name: "b"}}},
+			contentType: "text/plain; charset=utf-8",
+			body:        "a=1\r\nb=x=y\r\n",
+			expected:    map[string]any{"a": "1", "b": "x=y"},
+		},
+		"json": {
+			action:      Action{Name: "add-item", Href: "/orders/42/items", Method: http.MethodPost, Type: "application/json", Fields: addItem.Fields},
+			contentType: "application/json",
+			body:        `{"orderNumber":"43","quantity":2}`,
+			expected:    map[string]any{"orderNumber": "43", "quantity": json.Number("2")},
+		},
+		"json empty": {
+			action:      Action{Name: "ping", Href: "/ping", Method: http.MethodPost, Type: "application/json"},
+			contentType: "application/json",
+			expected:    map[string]any{},
+		},
+	}
+
+	for name, s := range specs {
+		t.Run(name, func(t *testing.T) {
+			method, target := s.method, s.target
+			if method == "" {
+				method, target = http.MethodPost, "/orders/42/items"
+			}
+
+			req := httptest.NewRequest(method, target, strings.NewReader(s.body))
+			if s.contentType != "" {
+				req.Header.Set("content-type", s.contentType)
+			}
+
+			var data map[string]any
+			require.NoError(t, s.action.Decode(req, &data))
+			require.Equal(t, s.expected, data)
+		})
+	}
+}
+
+func TestActionDecodeMultipart(t *testing.T) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	require.NoError(t, w.WriteField("title", "Holiday"))
+	part, err := w.CreateFormFile("photo", "beach.jpg")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("jpeg data"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	action := Action{
+		Name:   "upload",
+		Href:   "/photos",
+		Method: http.MethodPost,
+		Type:   "multipart/form-data",
+		Fields: []ActionField{{Name: "title"}, {Name: "photo", Type: "file"}},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/photos", &buf)
+	req.Header.Set("content-type", w.FormDataContentType())
+
+	var upload struct {
+		Title string
+		Photo *multipart.FileHeader `json:"photo"`
+	}
+	require.NoError(t, action.Decode(req, &upload))
+	require.Equal(t, "Holiday", upload.Title)
+	require.Equal(t, "beach.jpg", upload.Photo.Filename)
+
+	f, err := upload.Photo.Open()
+	require.NoError(t, err)
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, "jpeg data", string(b))
+}
+
+func TestActionDecodeStruct(t *testing.T) {
+	type Base struct {
+		OrderNumber int `json:"orderNumber"`
+	}
+
+	type item struct {
+		Base
+		ProductCode string    `json:"productCode"`
+		Quantity    uint      `json:"quantity"`
+		Gift        bool      `json:"gift"`
+		Tags        []string  `json:"tags"`
+		Deliver     time.Time `json:"deliver"`
+		Note        *string   `json:"note"`
+		Ignored     string    `json:"-"`
+	}
+
+	action := Action{
+		Name:   "add-item",
+		Href:   "/orders/42/items",
+		Method: http.MethodPost,
+		Fields: []ActionField{
+			{Name: "orderNumber", Type: "hidden", Value: "42"},
+			{Name: "productCode"},
+			{Name: "quantity", Type: "number"},
+			{Name: "gift", Type: "checkbox"},
+			{Name: "tags"},
+			{Name: "deliver", Type: "date"},
+			{Name: "note"},
+		},
+	}
+
+	body := "productCode=ABC-123&quantity=2&gift=on&tags=red&deliver=2024-01-02&note=hello"
+	req := httptest.NewRequest(http.MethodPost, "/orders/42/items", strings.NewReader(body))
+	req.Header.Set("content-type", "application/x-www-form-urlencoded")
+
+	var actual item
+	require.NoError(t, action.Decode(req, &actual))
+
+	note := "hello"
+	require.Equal(t, item{
+		Base:        Base{OrderNumber: 42},
+		ProductCode: "ABC-123",
+		Quantity:    2,
+		Gift:        true,
+		Tags:        []string{"red"},
+		Deliver:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Note:        &note,
+	}, actual)
+}
+
+func TestActionDecodeStructTags(t *testing.T) {
+	action := Action{
+		Name:   "add-item",
+		Href:   "/orders/42/items",
+		Method: http.MethodPost,
+		Fields: []ActionField{{Name: "qty"}, {Name: "quantity"}, {Name: "code"}, {Name: "Note"}, {Name: "title"}},
+	}
+
+	var actual struct {
+		Quantity int    `siren:"prop,qty" json:"quantity"`
+		Code     string `siren:"prop" json:"code"`
+		Note     string
+		Secret   string `siren:"-" json:"title"`
+		Title    string `siren:"title"`
+	}
+
+	body := "qty=2&quantity=3&code=ABC-123&Note=hello&title=ignored"
+	req := httptest.NewRequest(http.MethodPost, "/orders/42/items", strings.NewReader(body))
+	req.Header.Set("content-type", "application/x-www-form-urlencoded")
+
+	require.NoError(t, action.Decode(req, &actual))
+	require.Equal(t, 2, actual.Quantity, "the siren tag takes precedence over the json tag")
+	require.Equal(t, "ABC-123", actual.Code, "the json tag is used when the siren tag has no name")
+	require.Equal(t, "hello", actual.Note)
+	require.Empty(t, actual.Secret, "fields skipped by the siren tag are not decoded")
+	require.Empty(t, actual.Title, "only property fields are decoded")
+}
+
+func TestActionDecodeJSONStruct(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+	}
+
+	type order struct {
+		Total   float64        `json:"total"`
+		Items   []int          `json:"items"`
+		Address address        `json:"address"`
+		Meta    map[string]int `json:"meta"`
+	}
+
+	action := Action{
+		Name:   "create-order",
+		Href:   "/orders",
+		Method: http.MethodPost,
+		Type:   "application/json",
+		Fields: []ActionField{{Name: "total"}, {Name: "items"}, {Name: "address"}, {Name: "meta"}},
+	}
+
+	body := `{"total":12.5,"items":[1,2],"address":{"city":"Paris"},"meta":{"priority":1}}`
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set("content-type", "application/json")
+
+	var actual order
+	require.NoError(t, action.Decode(req, &actual))
+	require.Equal(t, order{
+		Total:   12.5,
+		Items:   []int{1, 2},
+		Address: address{City: "Paris"},
+		Meta:    map[string]int{"priority": 1},
+	}, actual)
+}
+
+func TestActionDecodeErrors(t *testing.T) {
+	type spec struct {
+		action      *Action
+		contentType string
+		body        string
+		target      any
+		expected    error
+		message     string
+	}
+
+	specs := map[string]spec{
+		"unknown field": {
+			contentType: "application/x-www-form-urlencoded",
+			body:        "productCode=ABC-123&colour=red",
+			target:      new(map[string]any),
+			expected:    ErrUnknownField,
+			message:     `siren: unknown field: "colour"`,
+		},
+		"wrong content-type": {
+			contentType: "application/json",
+			body:        `{}`,
+			target:      new(map[string]any),
+			expected:    ErrUnsupportedMediaType,
+			message:     "siren: unsupported media type: application/json",
+		},
+		"invalid value": {
+			contentType: "application/x-www-form-urlencoded",
+			body:        "quantity=lots",
+			target:      new(struct{ Quantity int }),
+			message:     `field "quantity": cannot parse "lots" as an integer`,
+		},
+		"trailing json": {
+			action:      &Action{Name: "add-item", Href: "/orders/42/items", Method: http.MethodPost, Type: "application/json", Fields: addItem.Fields},
+			contentType: "application/json",
+			body:        `{"productCode":"ABC-123"} {"quantity":2}`,
+			target:      new(map[string]any),
+			message:     "siren: unexpected data after JSON body",
+		},
+		"conflicting brackets": {
+			contentType: "application/x-www-form-urlencoded",
+			body:        "productCode=a&productCode%5Bx%5D=b",
+			target:      new(map[string]any),
+			message:     `field "productCode[x]": conflicting values`,
+		},
+		"body too large": {
+			contentType: "application/x-www-form-urlencoded",
+			body:        "productCode=" + strings.Repeat("a", 10<<20),
+			target:      new(map[string]any),
+			message:     "http: request body too large",
+		},
+		"not a pointer": {
+			contentType: "application/x-www-form-urlencoded",
+			target:      map[string]any{},
+			message:     "siren: decode requires a non-nil pointer, got map[string]interface {}",
+		},
+	}
+
+	for name, s := range specs {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/orders/42/items", strings.NewReader(s.body))
+			req.Header.Set("content-type", s.contentType)
+
+			action := addItem
+			if s.action != nil {
+				action = *s.action
+			}
+
+			err := action.Decode(req, s.target)
+			if s.expected != nil {
+				require.ErrorIs(t, err, s.expected)
+			}
+			require.EqualError(t, err, s.message)
+		})
+	}
+}
+
+func TestActionDecodeClientSubmit(t *testing.T) {
+	type address struct {
+		City  string   `json:"city"`
+		Lines []string `json:"lines"`
+	}
+
+	var actual struct {
+		Name    string  `json:"name"`
+		Address address `json:"address"`
+	}
+
+	action := Action{
+		Name:   "ship",
+		Method: http.MethodPost,
+		Fields: []ActionField{{Name: "name"}, {Name: "address"}},
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := action.Decode(r, &actual); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	action.Href = Href(ts.URL)
+	c := client.New(client.WithBracketNotation())
+	_, err := c.Submit(action, map[string]any{
+		"name": "Jane",
+		"address": map[string]any{
+			"city":  "Paris",
+			"lines": []string{"1 Rue de Rivoli", "Apt 2"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Jane", actual.Name)
+	require.Equal(t, address{City: "Paris", Lines: []string{"1 Rue de Rivoli", "Apt 2"}}, actual.Address)
+}
+
+func TestActionDecodeUnsupportedType(t *testing.T) {
+	action := Action{Name: "import", Href: "/import", Method: http.MethodPost, Type: "application/xml"}
+	req := httptest.NewRequest(http.MethodPost, "/import", strings.NewReader("<import/>"))
+	req.Header.Set("content-type", "application/xml")
+
+	require.ErrorIs(t, action.Decode(req, new(map[string]any)), ErrUnsupportedMediaType)
+}
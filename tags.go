@@ -0,0 +1,276 @@
+package siren
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// fieldKind describes which part of an entity a struct field maps to.
+type fieldKind int
+
+const (
+	fieldProperty fieldKind = iota
+	fieldEntity
+	fieldTitle
+	fieldClass
+)
+
+// structField is a field of a struct, described by its siren tag. The tag is
+// a kind optionally followed by a name and options, such as
+// `siren:"prop,name,omitempty"` or `siren:"entity,rel=item"`. The kinds are:
+//
+//   - prop: the field is a property, which is the default for untagged fields
+//   - entity: the field is a sub-entity, or a slice of them, with the given
+//     rel and class options (eg: "rel=item,class=order")
+//   - title: the field is the title of the entity
+//   - class: the field is a class name, or a slice of them
+//
+// When a property has no name in its siren tag, the name from its json tag is
+// used, falling back to the field name, just like encoding/json. The omitempty
+// option from the json tag is also respected.
+type structField struct {
+	name      string
+	tagged    bool
+	kind      fieldKind
+	index     []int
+	omitEmpty bool
+	rels      Rels
+	classes   Classes
+}
+
+type fieldList []structField
+
+// lookup finds the property with the given name, preferring an exact match
+// over a case-insensitive one.
+func (l fieldList) lookup(name string) (structField, bool) {
+	for _, f := range l {
+		if f.kind == fieldProperty && f.name == name {
+			return f, true
+		}
+	}
+	for _, f := range l {
+		if f.kind == fieldProperty && strings.EqualFold(f.name, name) {
+			return f, true
+		}
+	}
+	return structField{}, false
+}
+
+// structFields lists the exported fields of a struct. The fields of embedded
+// structs are flattened, and fields tagged with siren:"-" (or json:"-" when
+// there is no siren tag) are skipped.
+//
+// When several properties have the same name, the encoding/json rules decide
+// which one is used: the least nested one wins, then one whose name came from
+// a tag. When that still leaves more than one, they are all dropped.
+func structFields(t reflect.Type) (fieldList, error) {
+	fields, err := collectFields(t, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]fieldList)
+	for _, f := range fields {
+		if f.kind == fieldProperty {
+			byName[f.name] = append(byName[f.name], f)
+		}
+	}
+
+	var visible fieldList
+	for _, f := range fields {
+		if f.kind == fieldProperty {
+			dominant, ok := dominantField(byName[f.name])
+			if !ok || !sameIndex(dominant.index, f.index) {
+				continue
+			}
+		}
+		visible = append(visible, f)
+	}
+	return visible, nil
+}
+
+// dominantField picks the field that wins among fields with the same name,
+// returning false when there is no single winner.
+func dominantField(fields fieldList) (structField, bool) {
+	depth := len(fields[0].index)
+	for _, f := range fields[1:] {
+		if len(f.index) < depth {
+			depth = len(f.index)
+		}
+	}
+
+	var shallowest, tagged fieldList
+	for _, f := range fields {
+		if len(f.index) == depth {
+			shallowest = append(shallowest, f)
+			if f.tagged {
+				tagged = append(tagged, f)
+			}
+		}
+	}
+
+	switch {
+	case len(shallowest) == 1:
+		return shallowest[0], true
+	case len(tagged) == 1:
+		return tagged[0], true
+	}
+	return structField{}, false
+}
+
+func sameIndex(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func collectFields(t reflect.Type, index []int) (fieldList, error) {
+	var fields fieldList
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		f, ok, err := parseField(sf)
+		if err != nil {
+			return nil, fmt.Errorf("siren: field %s of %s: %w", sf.Name, t, err)
+		}
+		if !ok {
+			continue
+		}
+
+		if sf.Anonymous && f.kind == fieldProperty && f.name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded, err := collectFields(ft, fieldIndex)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, embedded...)
+				continue
+			}
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+		if f.name == "" {
+			f.name = sf.Name
+		} else {
+			f.tagged = true
+		}
+		f.index = fieldIndex
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// parseField reads the siren and json tags of a field, returning false when the
+// field should be skipped. The name is left empty when neither tag sets it.
+func parseField(sf reflect.StructField) (structField, bool, error) {
+	var f structField
+
+	jsonTag := sf.Tag.Get("json")
+	jsonName, jsonOpts, _ := strings.Cut(jsonTag, ",")
+
+	tag, ok := sf.Tag.Lookup("siren")
+	if !ok {
+		if jsonTag == "-" {
+			return f, false, nil
+		}
+		f.name = jsonName
+		f.omitEmpty = hasOption(jsonOpts, "omitempty")
+		return f, true, nil
+	}
+
+	if tag == "-" {
+		return f, false, nil
+	}
+
+	parts := strings.Split(tag, ",")
+	switch parts[0] {
+	case "", "prop":
+		f.kind = fieldProperty
+	case "entity":
+		f.kind = fieldEntity
+	case "title":
+		f.kind = fieldTitle
+	case "class":
+		f.kind = fieldClass
+	default:
+		return f, false, fmt.Errorf("unknown kind %q in siren tag", parts[0])
+	}
+
+	for _, opt := range parts[1:] {
+		key, value, isPair := strings.Cut(opt, "=")
+		switch {
+		case opt == "omitempty":
+			f.omitEmpty = true
+		case isPair && key == "rel":
+			f.rels = append(f.rels, Href(value))
+		case isPair && key == "class":
+			f.classes = append(f.classes, value)
+		case !isPair && f.name == "" && opt != "":
+			f.name = opt
+		default:
+			return f, false, fmt.Errorf("unknown option %q in siren tag", opt)
+		}
+	}
+
+	if f.kind == fieldProperty && f.name == "" && jsonTag != "-" {
+		f.name = jsonName
+	}
+	if f.kind == fieldEntity && len(f.rels) == 0 {
+		return f, false, fmt.Errorf("entity requires a rel in siren tag")
+	}
+
+	return f, true, nil
+}
+
+func hasOption(opts, option string) bool {
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but allocates nil embedded
+// struct pointers along the way.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for x, i := range index {
+		if x > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v
+}
+
+// fieldByIndexValue is like reflect.Value.FieldByIndex, but returns false
+// rather than panicking when an embedded struct pointer is nil.
+func fieldByIndexValue(v reflect.Value, index []int) (reflect.Value, bool) {
+	for x, i := range index {
+		if x > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v, true
+}
@@ -0,0 +1,381 @@
+package siren
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidTemplate is used when an href is not a valid URI Template.
+var ErrInvalidTemplate = errors.New("siren: invalid uri template")
+
+// IsTemplated reports whether this href is a URI Template (RFC 6570) that
+// contains at least one expression, such as "/orders{?status,page}".
+func (h Href) IsTemplated() bool {
+	s := string(h)
+	open := strings.IndexByte(s, '{')
+	return open >= 0 && strings.IndexByte(s[open:], '}') > 0
+}
+
+// Expand treats this href as a URI Template (RFC 6570) and expands it with the
+// given variables, supporting every expression up to level 4.
+//
+// Values may be strings, numbers or booleans, slices for list values, and maps
+// with string keys for associative array values. Since Go maps are unordered,
+// the pairs of associative arrays are expanded sorted by key. Variables that
+// are missing, nil, or empty lists and maps are undefined and are skipped.
+//
+// When the template is malformed, ErrInvalidTemplate is returned.
+func (h Href) Expand(vars map[string]any) (Href, error) {
+	var b strings.Builder
+	s := string(h)
+
+	for len(s) > 0 {
+		open := strings.IndexByte(s, '{')
+		if open < 0 {
+			if strings.IndexByte(s, '}') >= 0 {
+				return "", fmt.Errorf("%w: unexpected '}' in %q", ErrInvalidTemplate, h)
+			}
+			b.WriteString(encodeTemplate(s, true))
+			break
+		}
+
+		literal := s[:open]
+		if strings.IndexByte(literal, '}') >= 0 {
+			return "", fmt.Errorf("%w: unexpected '}' in %q", ErrInvalidTemplate, h)
+		}
+		b.WriteString(encodeTemplate(literal, true))
+
+		end := strings.IndexByte(s[open:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("%w: unclosed expression in %q", ErrInvalidTemplate, h)
+		}
+
+		if err := expandExpression(&b, s[open+1:open+end], vars); err != nil {
+			return "", err
+		}
+		s = s[open+end+1:]
+	}
+
+	return Href(b.String()), nil
+}
+
+// templateOperator holds the expansion behavior for each operator, as listed
+// in RFC 6570 appendix A.
+type templateOperator struct {
+	first    string
+	sep      string
+	named    bool
+	ifEmpty  string
+	reserved bool
+}
+
+var templateOperators = map[byte]templateOperator{
+	0:   {first: "", sep: ",", named: false, ifEmpty: "", reserved: false},
+	'+': {first: "", sep: ",", named: false, ifEmpty: "", reserved: true},
+	'.': {first: ".", sep: ".", named: false, ifEmpty: "", reserved: false},
+	'/': {first: "/", sep: "/", named: false, ifEmpty: "", reserved: false},
+	';': {first: ";", sep: ";", named: true, ifEmpty: "", reserved: false},
+	'?': {first: "?", sep: "&", named: true, ifEmpty: "=", reserved: false},
+	'&': {first: "&", sep: "&", named: true, ifEmpty: "=", reserved: false},
+	'#': {first: "#", sep: ",", named: false, ifEmpty: "", reserved: true},
+}
+
+type varSpec struct {
+	name    string
+	prefix  int
+	explode bool
+}
+
+func expandExpression(b *strings.Builder, expr string, vars map[string]any) error {
+	if expr == "" {
+		return fmt.Errorf("%w: empty expression", ErrInvalidTemplate)
+	}
+
+	var opKey byte
+	if strings.IndexByte("+#./;?&", expr[0]) >= 0 {
+		opKey = expr[0]
+		expr = expr[1:]
+	} else if strings.IndexByte("=,!@|", expr[0]) >= 0 {
+		return fmt.Errorf("%w: reserved operator %q", ErrInvalidTemplate, expr[0])
+	}
+	op := templateOperators[opKey]
+
+	specs := strings.Split(expr, ",")
+	first := true
+	for _, raw := range specs {
+		spec, err := parseVarSpec(raw)
+		if err != nil {
+			return err
+		}
+
+		value, ok := templateValue(vars[spec.name])
+		if !ok {
+			continue
+		}
+
+		if first {
+			b.WriteString(op.first)
+			first = false
+		} else {
+			b.WriteString(op.sep)
+		}
+
+		if err := expandValue(b, op, spec, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseVarSpec(raw string) (varSpec, error) {
+	spec := varSpec{name: raw}
+
+	if strings.HasSuffix(raw, "*") {
+		spec.explode = true
+		spec.name = raw[:len(raw)-1]
+	} else if colon := strings.IndexByte(raw, ':'); colon >= 0 {
+		spec.name = raw[:colon]
+		n, err := strconv.Atoi(raw[colon+1:])
+		if err != nil || n < 1 || n > 9999 || raw[colon+1] == '0' {
+			return spec, fmt.Errorf("%w: invalid prefix in %q", ErrInvalidTemplate, raw)
+		}
+		spec.prefix = n
+	}
+
+	if !isVarName(spec.name) {
+		return spec, fmt.Errorf("%w: invalid variable name %q", ErrInvalidTemplate, spec.name)
+	}
+
+	return spec, nil
+}
+
+// isVarName checks the varname production: varchars separated by single dots,
+// where varchars are ALPHA, DIGIT, "_" or pct-encoded triplets.
+func isVarName(name string) bool {
+	if name == "" || name[0] == '.' || name[len(name)-1] == '.' {
+		return false
+	}
+
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_':
+		case c == '.':
+			if name[i+1] == '.' {
+				return false
+			}
+		case c == '%':
+			if i+2 >= len(name) || !isHex(name[i+1]) || !isHex(name[i+2]) {
+				return false
+			}
+			i += 2
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// templateValue normalizes a variable into a string, a []string list or a
+// sorted list of key/value pairs, returning false when it is undefined.
+func templateValue(v any) (any, bool) {
+	if v == nil {
+		return nil, false
+	}
+
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case []string:
+		return v, len(v) > 0
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil, false
+		}
+		return templateValue(rv.Elem().Interface())
+
+	case reflect.Slice, reflect.Array:
+		var list []string
+		for i := 0; i < rv.Len(); i++ {
+			if item := rv.Index(i).Interface(); item != nil {
+				list = append(list, templateString(item))
+			}
+		}
+		return list, len(list) > 0
+
+	case reflect.Map:
+		var pairs [][2]string
+		for _, key := range rv.MapKeys() {
+			if value := rv.MapIndex(key).Interface(); value != nil {
+				pairs = append(pairs, [2]string{templateString(key.Interface()), templateString(value)})
+			}
+		}
+		sort.Slice(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+		return pairs, len(pairs) > 0
+	}
+
+	return templateString(v), true
+}
+
+func templateString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	}
+	return fmt.Sprint(v)
+}
+
+func expandValue(b *strings.Builder, op templateOperator, spec varSpec, value any) error {
+	switch value := value.(type) {
+	case string:
+		if op.named {
+			b.WriteString(spec.name)
+			if value == "" {
+				b.WriteString(op.ifEmpty)
+				return nil
+			}
+			b.WriteByte('=')
+		}
+		if spec.prefix > 0 {
+			value = truncateRunes(value, spec.prefix)
+		}
+		b.WriteString(encodeTemplate(value, op.reserved))
+
+	case []string:
+		if spec.prefix > 0 {
+			return fmt.Errorf("%w: prefix applied to list %q", ErrInvalidTemplate, spec.name)
+		}
+
+		if !spec.explode {
+			if op.named {
+				b.WriteString(spec.name + "=")
+			}
+			for x, item := range value {
+				if x > 0 {
+					b.WriteByte(',')
+				}
+				b.WriteString(encodeTemplate(item, op.reserved))
+			}
+			return nil
+		}
+
+		for x, item := range value {
+			if x > 0 {
+				b.WriteString(op.sep)
+			}
+			if op.named {
+				writeTemplatePair(b, op, spec.name, item)
+			} else {
+				b.WriteString(encodeTemplate(item, op.reserved))
+			}
+		}
+
+	case [][2]string:
+		if spec.prefix > 0 {
+			return fmt.Errorf("%w: prefix applied to associative array %q", ErrInvalidTemplate, spec.name)
+		}
+
+		if !spec.explode {
+			if op.named {
+				b.WriteString(spec.name + "=")
+			}
+			for x, pair := range value {
+				if x > 0 {
+					b.WriteByte(',')
+				}
+				b.WriteString(encodeTemplate(pair[0], op.reserved))
+				b.WriteByte(',')
+				b.WriteString(encodeTemplate(pair[1], op.reserved))
+			}
+			return nil
+		}
+
+		for x, pair := range value {
+			if x > 0 {
+				b.WriteString(op.sep)
+			}
+			if op.named {
+				writeTemplatePair(b, op, encodeTemplate(pair[0], op.reserved), pair[1])
+			} else {
+				b.WriteString(encodeTemplate(pair[0], op.reserved))
+				b.WriteByte('=')
+				b.WriteString(encodeTemplate(pair[1], op.reserved))
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeTemplatePair(b *strings.Builder, op templateOperator, name, value string) {
+	b.WriteString(name)
+	if value == "" {
+		b.WriteString(op.ifEmpty)
+		return
+	}
+	b.WriteByte('=')
+	b.WriteString(encodeTemplate(value, op.reserved))
+}
+
+func truncateRunes(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+// encodeTemplate percent-encodes every character that is not unreserved. When
+// reserved is true, reserved characters and existing pct-encoded triplets are
+// also allowed through.
+func encodeTemplate(s string, reserved bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case isUnreserved(c):
+			b.WriteByte(c)
+		case reserved && strings.IndexByte(":/?#[]@!$&'()*+,;=", c) >= 0:
+			b.WriteByte(c)
+		case reserved && c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			b.WriteString(s[i : i+3])
+			i += 3
+			continue
+		default:
+			_, size := utf8.DecodeRuneInString(s[i:])
+			for _, octet := range []byte(s[i : i+size]) {
+				fmt.Fprintf(&b, "%%%02X", octet)
+			}
+			i += size
+			continue
+		}
+		i++
+	}
+	return b.String()
+}
+
+func isUnreserved(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
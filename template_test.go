@@ -0,0 +1,92 @@
+package siren_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/dominicbarnes/go-siren"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestHrefExpand runs the examples from RFC 6570, along with templates that
+// must be rejected. Both files are laid out in the same format as the
+// uritemplate-test suite, but they are maintained here rather than copied.
+func TestHrefExpand(t *testing.T) {
+	runTemplateTests(t, "testdata/rfc6570-examples.json")
+	runTemplateTests(t, "testdata/invalid-templates.json")
+}
+
+// TestHrefExpandUpstream runs the unmodified files from the uritemplate-test
+// suite, see testdata/uritemplate-test/README.md.
+func TestHrefExpandUpstream(t *testing.T) {
+	files, err := filepath.Glob("testdata/uritemplate-test/*.json")
+	require.NoError(t, err)
+	if len(files) == 0 {
+		t.Skip("uritemplate-test files have not been copied into testdata/uritemplate-test")
+	}
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			runTemplateTests(t, file)
+		})
+	}
+}
+
+// runTemplateTests runs a file in the uritemplate-test format. Where the
+// expected value is a list, any of the values is acceptable, and where it is
+// false the template must be rejected.
+func runTemplateTests(t *testing.T, file string) {
+	b, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	var groups map[string]struct {
+		Variables map[string]any `json:"variables"`
+		Testcases [][2]any       `json:"testcases"`
+	}
+	require.NoError(t, json.Unmarshal(b, &groups))
+
+	for name, group := range groups {
+		t.Run(name, func(t *testing.T) {
+			for _, tc := range group.Testcases {
+				template := tc[0].(string)
+				actual, err := Href(template).Expand(group.Variables)
+
+				switch expected := tc[1].(type) {
+				case bool:
+					require.ErrorIs(t, err, ErrInvalidTemplate, template)
+				case string:
+					require.NoError(t, err, template)
+					require.Equal(t, expected, string(actual), template)
+				case []any:
+					require.NoError(t, err, template)
+					require.Contains(t, expected, string(actual), template)
+				}
+			}
+		})
+	}
+}
+
+func TestHrefExpandValues(t *testing.T) {
+	actual, err := Href("/orders{?status*,page,active,since}").Expand(map[string]any{
+		"status": []string{"open", "pending"},
+		"page":   2,
+		"active": true,
+		"since":  (*string)(nil),
+	})
+	require.NoError(t, err)
+	require.Equal(t, Href("/orders?status=open&status=pending&page=2&active=true"), actual)
+
+	actual, err = Href("/search{?q}").Expand(map[string]any{"q": "café & crêpes"})
+	require.NoError(t, err)
+	require.Equal(t, Href("/search?q=caf%C3%A9%20%26%20cr%C3%AApes"), actual)
+}
+
+func TestHrefIsTemplated(t *testing.T) {
+	require.True(t, Href("/orders{?status,page}").IsTemplated())
+	require.True(t, Href("{+base}/orders").IsTemplated())
+	require.False(t, Href("/orders").IsTemplated())
+	require.False(t, Href("/orders}{").IsTemplated())
+}
@@ -0,0 +1,134 @@
+package siren
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	validator "gopkg.in/validator.v2"
+)
+
+// ErrZeroValue is used when a required attribute is missing.
+var ErrZeroValue = validator.ErrZeroValue
+
+// ValidationError is a single problem found while validating an entity. The
+// path is a JSON pointer to the offending attribute within the document, such
+// as "/entities/2/actions/0/fields/1/name".
+type ValidationError struct {
+	Path string
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors is a report of every problem found while validating an
+// entity. It supports errors.Is and errors.As for inspecting the individual
+// problems.
+type ValidationErrors []*ValidationError
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for x, err := range e {
+		msgs[x] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns each of the individual problems.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for x, err := range e {
+		errs[x] = err
+	}
+	return errs
+}
+
+func (e Entity) validate(path string) ValidationErrors {
+	errs := validateStruct(e, path)
+
+	for x, ee := range e.Entities {
+		errs = append(errs, ee.validate(pointer(path, "entities", x))...)
+	}
+
+	for x, l := range e.Links {
+		errs = append(errs, validateStruct(l, pointer(path, "links", x))...)
+	}
+
+	for x, a := range e.Actions {
+		errs = append(errs, a.validate(pointer(path, "actions", x))...)
+	}
+
+	return errs
+}
+
+func (e EmbeddedEntity) validate(path string) ValidationErrors {
+	errs := validateStruct(e, path)
+
+	if e.IsLink() && (len(e.Properties) > 0 || len(e.Entities) > 0 || len(e.Links) > 0 || len(e.Actions) > 0) {
+		errs = append(errs, &ValidationError{Path: path, Err: ErrMixedEmbeddedEntity})
+	}
+	if e.IsRepresentation() && e.Type != "" {
+		errs = append(errs, &ValidationError{Path: path + "/type", Err: ErrTypedEmbeddedRepresentation})
+	}
+
+	return append(errs, e.Entity.validate(path)...)
+}
+
+func (a Action) validate(path string) ValidationErrors {
+	errs := validateStruct(a, path)
+
+	for x, f := range a.Fields {
+		errs = append(errs, validateStruct(f, pointer(path, "fields", x))...)
+	}
+
+	return errs
+}
+
+// validateStruct checks the validation tags of the direct fields of the given
+// struct, reporting each problem under the JSON name of the field. Nested
+// structs are skipped, as they are walked separately to build their paths.
+func validateStruct(v any, path string) ValidationErrors {
+	err := validator.Validate(v)
+	if err == nil {
+		return nil
+	}
+
+	var m validator.ErrorMap
+	if !errors.As(err, &m) {
+		return ValidationErrors{{Path: path, Err: err}}
+	}
+
+	var errs ValidationErrors
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		for _, ferr := range m[field.Name] {
+			errs = append(errs, &ValidationError{
+				Path: path + "/" + jsonName(field),
+				Err:  ferr,
+			})
+		}
+	}
+	return errs
+}
+
+func jsonName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" {
+		return name
+	}
+	return field.Name
+}
+
+func pointer(path, key string, index int) string {
+	return path + "/" + key + "/" + strconv.Itoa(index)
+}